@@ -0,0 +1,129 @@
+package agency
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jimezsa/jobcli/internal/company"
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// companyWords are company-name words used by staffing agencies and
+// recruiters ("Acme Recruiting", "XY Staffing Solutions").
+var companyWords = map[string]bool{
+	"recruiting": true, "recruitment": true, "recruiters": true, "staffing": true,
+	"headhunter": true, "headhunters": true, "headhunting": true,
+	"personalvermittlung": true, "personalberatung": true, "personaldienstleistung": true,
+	"personaldienstleistungen": true, "personalservice": true, "personalleasing": true,
+	"zeitarbeit": true, "arbeitnehmerüberlassung": true,
+}
+
+// companyStems catch German compounds such as "Zeitarbeitsfirma" or
+// "Personaldienstleistungsgesellschaft".
+var companyStems = []string{"personaldienst", "personalvermittl", "zeitarbeit"}
+
+// clientPhrases are description phrases of postings written for a client
+// rather than the employer itself. Descriptions are lowercased with
+// collapsed whitespace and phrases match whole words only, so "for our
+// client" does not match an employer writing "for our clients".
+var clientPhrases = []string{
+	"für unseren kunden",
+	"für einen unserer kunden",
+	"für unseren mandanten",
+	"für einen unserer mandanten",
+	"im auftrag unseres kunden",
+	"im auftrag unserer kunden",
+	"im auftrag unseres mandanten",
+	"im rahmen der arbeitnehmerüberlassung",
+	"im rahmen der direktvermittlung",
+	"im rahmen einer direktvermittlung",
+	"on behalf of our client",
+	"on behalf of a client",
+	"for our client",
+	"for one of our clients",
+	"our client is a",
+	"our client is an",
+}
+
+// Apply sets job.Agency when the posting looks like it comes from a staffing
+// agency or recruiter. It never clears a flag set earlier.
+func Apply(job *models.Job) {
+	if job == nil {
+		return
+	}
+	if !job.Agency {
+		job.Agency = Detect(*job)
+	}
+}
+
+// Detect reports whether job is posted by a known agency, by a company whose
+// name marks it as one, or with a description written for a client.
+func Detect(job models.Job) bool {
+	return knownAgency(job.Company) || agencyName(job.Company) || clientPosting(job)
+}
+
+func knownAgency(name string) bool {
+	normalized := company.Normalize(name)
+	if normalized == "" {
+		return false
+	}
+	for _, agency := range knownNormalized {
+		if normalized == agency || strings.HasPrefix(normalized, agency+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func agencyName(name string) bool {
+	normalized := company.Normalize(name)
+	for _, word := range strings.Fields(normalized) {
+		if companyWords[word] {
+			return true
+		}
+		for _, stem := range companyStems {
+			if strings.HasPrefix(word, stem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func clientPosting(job models.Job) bool {
+	text := strings.Join(strings.Fields(strings.ToLower(job.Snippet+" "+job.Description)), " ")
+	if text == "" {
+		return false
+	}
+	for _, phrase := range clientPhrases {
+		if containsPhrase(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPhrase reports whether phrase occurs in text between word
+// boundaries.
+func containsPhrase(text string, phrase string) bool {
+	for start := 0; start < len(text); {
+		idx := strings.Index(text[start:], phrase)
+		if idx < 0 {
+			return false
+		}
+		idx += start
+		end := idx + len(phrase)
+		before, _ := utf8.DecodeLastRuneInString(text[:idx])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (idx == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			return true
+		}
+		start = idx + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
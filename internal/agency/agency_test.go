@@ -0,0 +1,47 @@
+package agency
+
+import (
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name string
+		job  models.Job
+		want bool
+	}{
+		{"known agency", models.Job{Company: "Hays Professional Solutions GmbH"}, true},
+		{"known agency with legal form", models.Job{Company: "Randstad Deutschland GmbH & Co. KG"}, true},
+		{"agency word", models.Job{Company: "Acme Recruiting GmbH"}, true},
+		{"german compound", models.Job{Company: "Müller Personaldienstleistungs GmbH"}, true},
+		{"client phrase de", models.Job{Company: "Acme GmbH", Description: "Für unseren\nKunden suchen wir ab sofort einen Entwickler."}, true},
+		{"client phrase en", models.Job{Company: "Acme", Snippet: "We are hiring on behalf of our client, a fintech."}, true},
+		{"client phrase before punctuation", models.Job{Company: "Acme", Description: "Hiring for our client: a fast-growing SaaS company."}, true},
+		{"employer", models.Job{Company: "Google Germany GmbH", Description: "Unsere Kunden stehen im Mittelpunkt."}, false},
+		{"employer serving clients", models.Job{Company: "Acme Software Ltd", Description: "We build solutions for our clients in banking. Our client, a bank, relies on us."}, false},
+		{"name sharing a prefix", models.Job{Company: "Hayson Robotics"}, false},
+		{"empty", models.Job{}, false},
+	}
+	for _, tc := range cases {
+		if got := Detect(tc.job); got != tc.want {
+			t.Errorf("%s: Detect() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	job := models.Job{Company: "Adecco"}
+	Apply(&job)
+	if !job.Agency {
+		t.Fatalf("Apply() Agency = false, want true")
+	}
+
+	job = models.Job{Company: "Acme", Agency: true}
+	Apply(&job)
+	if !job.Agency {
+		t.Fatalf("Apply() cleared Agency")
+	}
+	Apply(nil)
+}
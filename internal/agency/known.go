@@ -0,0 +1,52 @@
+package agency
+
+import "github.com/jimezsa/jobcli/internal/company"
+
+// Known lists staffing agencies and recruiters by the name they post under.
+// A company matches when its normalized name equals an entry or starts with
+// it ("Hays Professional Solutions GmbH" matches "Hays"), so keep entries
+// specific enough not to prefix an employer's name. Keep the list sorted.
+var Known = []string{
+	"Adecco",
+	"Aerotek",
+	"Akkodis",
+	"Allegis Group",
+	"Amadeus FiRe",
+	"Brunel",
+	"Computer Futures",
+	"Experis",
+	"Frank Recruitment Group",
+	"GULP",
+	"Harvey Nash",
+	"Hays",
+	"I.K. Hofmann",
+	"Jefferson Frank",
+	"Kelly Services",
+	"ManpowerGroup",
+	"Michael Page",
+	"Modis",
+	"Nigel Frank",
+	"Orizon",
+	"Page Personnel",
+	"Persona service",
+	"Progressive Recruitment",
+	"Randstad",
+	"Robert Half",
+	"Robert Walters",
+	"Spencer Ogden",
+	"TEKsystems",
+	"Tempton",
+	"Trenkwalder",
+}
+
+var knownNormalized = normalizeKnown(Known)
+
+func normalizeKnown(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if normalized := company.Normalize(name); normalized != "" {
+			out = append(out, normalized)
+		}
+	}
+	return out
+}
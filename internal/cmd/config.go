@@ -8,7 +8,7 @@ import (
 )
 
 type ConfigCmd struct {
-	Init InitConfigCmd `cmd:"" help:"Write default config and proxies files."`
+	Init InitConfigCmd `cmd:"" help:"Write default config, proxies and companies files."`
 	Path PathConfigCmd `cmd:"" help:"Print config directory."`
 }
 
@@ -22,6 +22,7 @@ type CLI struct {
 	ZipRecruiter SiteCmd    `cmd:"" name:"ziprecruiter" help:"Search ZipRecruiter."`
 	Stepstone    SiteCmd    `cmd:"" name:"stepstone" help:"Search Stepstone."`
 	Seen         SeenCmd    `cmd:"" help:"Seen jobs utilities."`
+	Track        TrackCmd   `cmd:"" help:"Track application status of seen jobs."`
 	Proxies      ProxiesCmd `cmd:"" help:"Proxy utilities."`
 }
 
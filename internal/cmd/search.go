@@ -11,13 +11,22 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
-	"sync"
+	"text/template"
 	"time"
 
+	"github.com/jimezsa/jobcli/internal/agency"
+	"github.com/jimezsa/jobcli/internal/company"
 	"github.com/jimezsa/jobcli/internal/config"
+	"github.com/jimezsa/jobcli/internal/dedupe"
 	"github.com/jimezsa/jobcli/internal/export"
+	"github.com/jimezsa/jobcli/internal/filter"
+	"github.com/jimezsa/jobcli/internal/fsutil"
+	"github.com/jimezsa/jobcli/internal/location"
 	"github.com/jimezsa/jobcli/internal/models"
 	"github.com/jimezsa/jobcli/internal/network"
+	"github.com/jimezsa/jobcli/internal/normalize"
+	"github.com/jimezsa/jobcli/internal/rank"
+	"github.com/jimezsa/jobcli/internal/salary"
 	"github.com/jimezsa/jobcli/internal/scraper"
 	"github.com/jimezsa/jobcli/internal/seen"
 	"github.com/jimezsa/jobcli/internal/ui"
@@ -38,24 +47,44 @@ type SiteCmd struct {
 }
 
 type SearchOptions struct {
-	Location   string `help:"Job location." env:"JOBCLI_DEFAULT_LOCATION"`
-	Country    string `help:"Country code (Indeed/Glassdoor)." env:"JOBCLI_DEFAULT_COUNTRY"`
-	Limit      int    `help:"Maximum results per query." env:"JOBCLI_DEFAULT_LIMIT"`
-	Offset     int    `help:"Offset for pagination."`
-	Remote     bool   `help:"Remote-only roles."`
-	JobType    string `help:"Job type filter (fulltime, parttime, contract, internship)." enum:",fulltime,parttime,contract,internship" default:""`
-	Hours      int    `help:"Jobs posted in the last N hours."`
-	Format     string `help:"Output format: csv, json, md." enum:",csv,json,md" default:""`
-	Links      string `help:"Table link display: short or full." enum:"short,full" default:"full"`
-	Output     string `name:"output" short:"o" help:"Write output to a file."`
-	Out        string `name:"out" help:"Alias for --output."`
-	File       string `name:"file" help:"Alias for --output."`
-	Proxies    string `help:"Comma-separated proxy URLs." env:"JOBCLI_PROXIES"`
-	QueryFile  string `help:"Path to JSON file with queries or a full search profile."`
-	Seen       string `help:"Path to seen jobs JSON file."`
-	NewOnly    bool   `help:"Output only unseen jobs (requires --seen)."`
-	NewOut     string `help:"Write unseen jobs JSON to a file (requires --seen)."`
-	SeenUpdate bool   `help:"Update --seen history file by merging in newly discovered unseen jobs after search completes (requires --seen)."`
+	Location          string `help:"Job location." env:"JOBCLI_DEFAULT_LOCATION"`
+	Country           string `help:"Country code (Indeed/Glassdoor)." env:"JOBCLI_DEFAULT_COUNTRY"`
+	Limit             int    `help:"Maximum results per query." env:"JOBCLI_DEFAULT_LIMIT"`
+	Offset            int    `help:"Offset for pagination."`
+	Remote            bool   `help:"Remote-only roles (same as --arrangement remote)."`
+	Arrangement       string `help:"Comma-separated work arrangements to keep (remote, hybrid, onsite)."`
+	JobType           string `help:"Job type filter (fulltime, parttime, contract, internship)." enum:",fulltime,parttime,contract,internship" default:""`
+	Hours             int    `help:"Jobs posted in the last N hours."`
+	MinSalary         string `help:"Minimum annual salary, optionally with currency and period (e.g. 60000, \"60k EUR\", \"$40 an hour\")."`
+	Seniority         string `help:"Comma-separated seniority levels to keep (intern, junior, mid, senior, staff, lead, manager)."`
+	ExcludeSeniority  string `help:"Comma-separated seniority levels to drop (e.g. intern)."`
+	Include           string `help:"Keep only jobs matching an expression: terms, \"quoted phrases\", AND, OR, NOT, parentheses and title:, company:, location:, description: scoping (e.g. 'title:(engineer OR entwickler)')."`
+	Exclude           string `help:"Drop jobs matching an expression, in the --include syntax (e.g. 'title:(werkstudent OR praktikum OR sales)')."`
+	ExcludeCompanies  string `help:"Comma-separated companies to drop, with their aliases from companies.json in the config directory."`
+	OnlyCompanies     string `help:"Comma-separated companies to keep, with their aliases from companies.json in the config directory."`
+	ExcludeAgencies   bool   `help:"Drop postings by staffing agencies and recruiters (jobs with agency=true)."`
+	BoostCompanies    string `help:"Comma-separated companies listed first in the results, with their aliases from companies.json in the config directory."`
+	Radius            string `help:"Keep jobs within this distance of --location (e.g. 50km, 30mi)."`
+	StrictFilters     bool   `help:"Drop jobs whose posting date, work arrangement, job type, salary, seniority or location cannot be verified against --hours/--arrangement/--job-type/--min-salary/--seniority/--radius."`
+	Format            string `help:"Output format: csv, json, md, ndjson (one JSON object per line, streamed as each site finishes), html (self-contained report), rss, atom (feeds), xlsx, ods (spreadsheets; need --output or a redirect)." enum:",csv,json,md,ndjson,html,rss,atom,xlsx,ods" default:""`
+	Columns           string `help:"Comma-separated columns for table, CSV and TSV output (e.g. site,title,company,location,posted_at,salary)."`
+	Template          string `help:"Render results with a Go text/template file executed over the job list, instead of --format."`
+	Sort              string `help:"Sort results by posted_at, site, company, title, salary (annualized) or score (match with the queries); default site." enum:",posted_at,site,company,title,salary,score" default:""`
+	Desc              bool   `help:"Reverse the --sort order. Jobs without a posting date or salary stay last."`
+	GroupBy           string `name:"group-by" help:"Split table and md output into sections by site, company or query." enum:",site,company,query" default:""`
+	Links             string `help:"Table link display: short or full." enum:"short,full" default:"full"`
+	DescriptionFormat string `help:"Description format for exports: plain, markdown, html." enum:"plain,markdown,html" default:"plain"`
+	Output            string `name:"output" short:"o" help:"Write output to a file."`
+	Out               string `name:"out" help:"Alias for --output."`
+	File              string `name:"file" help:"Alias for --output."`
+	Proxies           string `help:"Comma-separated proxy URLs." env:"JOBCLI_PROXIES"`
+	QueryFile         string `help:"Path to JSON file with queries or a full search profile."`
+	Seen              string `help:"Path to seen jobs history: a JSON file or a SQLite store (.db)."`
+	NewOnly           bool   `help:"Output only unseen jobs (requires --seen)."`
+	NewOut            string `help:"Write unseen jobs JSON to a file (requires --seen)."`
+	SeenUpdate        bool   `help:"Update --seen history file after search completes: add unseen jobs and refresh last_seen of seen ones (requires --seen)."`
+	SeenKey           string `help:"How jobs are matched against --seen: title-company, title-company-location, url, id, fuzzy (default: the strategy recorded in the seen file, else title-company)." enum:",title-company,title-company-location,url,id,fuzzy" default:""`
+	SeenBackups       int    `help:"Keep N rolling backups of --seen (<seen>.bak.1 is the newest) when --seen-update rewrites it."`
 }
 
 const maxQueries = 10
@@ -89,23 +118,114 @@ func runSearch(ctx *Context, query string, sitesArg string, opts SearchOptions,
 	if opts.SeenUpdate && strings.TrimSpace(opts.Seen) == "" {
 		return fmt.Errorf("--seen-update requires --seen")
 	}
+	if opts.SeenBackups < 0 {
+		return fmt.Errorf("--seen-backups must be >= 0")
+	}
+	if opts.SeenBackups > 0 && !opts.SeenUpdate {
+		return fmt.Errorf("--seen-backups requires --seen-update")
+	}
+	seenKey, err := seen.ParseKeyStrategy(opts.SeenKey)
+	if err != nil {
+		return err
+	}
 
 	queries, err := mergeAndNormalizeQueries(splitQueries(query), queryConfig.Queries)
 	if err != nil {
 		return err
 	}
 
+	minSalary, minSalaryCurrency, err := parseMinSalary(opts.MinSalary)
+	if err != nil {
+		return err
+	}
+	arrangements, err := parseArrangementList(opts.Arrangement, opts.Remote)
+	if err != nil {
+		return err
+	}
+	seniority, err := parseSeniorityList("--seniority", opts.Seniority)
+	if err != nil {
+		return err
+	}
+	excludeSeniority, err := parseSeniorityList("--exclude-seniority", opts.ExcludeSeniority)
+	if err != nil {
+		return err
+	}
+	include, err := parseExpr("--include", opts.Include)
+	if err != nil {
+		return err
+	}
+	exclude, err := parseExpr("--exclude", opts.Exclude)
+	if err != nil {
+		return err
+	}
+	companies, err := loadCompanyList(opts)
+	if err != nil {
+		return err
+	}
+	boostCompanies := companies.Resolve(company.ParseNames(opts.BoostCompanies))
+
 	cfg := ctx.Config
 	baseParams := models.SearchParams{
 		Location: firstNonEmpty(opts.Location, cfg.DefaultLocation),
 		Country:  firstNonEmpty(opts.Country, cfg.DefaultCountry),
 		Limit:    defaultInt(opts.Limit, cfg.DefaultLimit),
 		Offset:   opts.Offset,
-		Remote:   opts.Remote,
+		Remote:   len(arrangements) == 1 && arrangements[0] == models.WorkArrangementRemote,
 		JobType:  opts.JobType,
 		Hours:    opts.Hours,
 	}
 
+	origin, radiusKm, err := parseRadius(opts.Radius, baseParams.Location, baseParams.Country)
+	if err != nil {
+		return err
+	}
+	criteria := filter.Criteria{
+		Hours:             baseParams.Hours,
+		Arrangements:      arrangements,
+		JobType:           baseParams.JobType,
+		MinSalary:         minSalary,
+		MinSalaryCurrency: minSalaryCurrency,
+		Seniority:         seniority,
+		ExcludeSeniority:  excludeSeniority,
+		Origin:            origin,
+		RadiusKm:          radiusKm,
+		Include:           include,
+		Exclude:           exclude,
+		OnlyCompanies:     companies.Resolve(company.ParseNames(opts.OnlyCompanies)),
+		ExcludeCompanies:  companies.Resolve(company.ParseNames(opts.ExcludeCompanies)),
+		ExcludeAgencies:   opts.ExcludeAgencies,
+		Strict:            opts.StrictFilters,
+	}
+
+	outputPath := resolveOutputPath(opts)
+	if strings.TrimSpace(opts.NewOut) != "" && pathsEqual(outputPath, opts.NewOut) {
+		return fmt.Errorf("--new-out path must differ from --output")
+	}
+	if strings.TrimSpace(opts.Seen) != "" && pathsEqual(outputPath, opts.Seen) {
+		return fmt.Errorf("--output path must differ from --seen")
+	}
+	if strings.TrimSpace(opts.NewOut) != "" && pathsEqual(opts.NewOut, opts.Seen) {
+		return fmt.Errorf("--new-out path must differ from --seen")
+	}
+	format, err := resolveFormat(ctx, opts, outputPath)
+	if err != nil {
+		return err
+	}
+	layout, err := resolveLayout(opts, format)
+	if err != nil {
+		return err
+	}
+	sortKey, err := rank.ParseKey(opts.Sort)
+	if err != nil {
+		return fmt.Errorf("invalid --sort: %w", err)
+	}
+	if format == export.FormatNDJSON && (opts.Sort != "" || opts.Desc) {
+		return fmt.Errorf("--sort and --desc cannot be used with --format ndjson, which streams jobs unsorted")
+	}
+	if format == export.FormatNDJSON && len(boostCompanies) > 0 {
+		return fmt.Errorf("--boost-companies cannot be used with --format ndjson, which streams jobs unsorted")
+	}
+
 	proxies, err := config.LoadProxies(opts.Proxies)
 	if err != nil {
 		return err
@@ -133,32 +253,48 @@ func runSearch(ctx *Context, query string, sitesArg string, opts SearchOptions,
 		defer stopIndicator()
 	}
 
-	var (
-		jobs     []models.Job
-		failures []scraperFailure
-	)
-	for _, currentQuery := range queries {
-		queryJobs, queryFailures, runErr := runScrapersForQuery(selected, baseParams, currentQuery)
-		if runErr != nil {
-			return runErr
-		}
-		queryJobs = limitJobs(queryJobs, baseParams.Limit)
-		jobs = mergeUniqueJobs(jobs, queryJobs)
-		failures = append(failures, queryFailures...)
+	if format == export.FormatNDJSON {
+		return streamSearch(ctx, streamConfig{
+			opts:       opts,
+			queries:    queries,
+			scrapers:   selected,
+			params:     baseParams,
+			criteria:   criteria,
+			seenKey:    seenKey,
+			outputPath: outputPath,
+		})
 	}
 
-	sortJobsBySite(jobs)
+	jobs, failures, dedupeStats, err := searchJobs(selected, baseParams, queries)
+	if err != nil {
+		return err
+	}
+	jobs, filterStats := filter.Apply(jobs, criteria)
+
+	rank.Sort(jobs, sortKey, opts.Desc, queries)
+	if len(boostCompanies) > 0 {
+		rank.Boost(jobs, func(job models.Job) bool {
+			return boostCompanies.Contains(job.Company)
+		})
+	}
 	sortScraperFailures(failures)
 
 	reportScraperFailures(ctx, failures)
 
 	var unseenJobs []models.Job
+	var seenStats seenRunStats
+	var isNew func(models.Job) bool
+	observedJobs := jobs
 	if strings.TrimSpace(opts.Seen) != "" {
-		seenJobs, err := seen.ReadJobsAllowMissing(opts.Seen)
+		history, err := seen.ReadHistoryAllowMissing(opts.Seen)
 		if err != nil {
 			return fmt.Errorf("read --seen: %w", err)
 		}
-		unseenJobs, _ = seen.Diff(jobs, seenJobs)
+		strategy := seen.ResolveKeyStrategy(seenKey, history.KeyStrategy)
+		unseenJobs, _ = seen.Diff(jobs, history.Jobs, strategy)
+		isNew = newJobMatcher(unseenJobs, strategy)
+		seenStats.Reposted = seen.MarkReposts(jobs, history.Jobs, strategy)
+		jobs, seenStats.Ignored = seen.ApplyStatus(jobs, history.Jobs, strategy)
 	}
 
 	outputJobs := jobs
@@ -166,32 +302,17 @@ func runSearch(ctx *Context, query string, sitesArg string, opts SearchOptions,
 		outputJobs = unseenJobs
 	}
 
-	outputPath := resolveOutputPath(opts)
-	if strings.TrimSpace(opts.NewOut) != "" && pathsEqual(outputPath, opts.NewOut) {
-		return fmt.Errorf("--new-out path must differ from --output")
-	}
-	if strings.TrimSpace(opts.Seen) != "" && pathsEqual(outputPath, opts.Seen) {
-		return fmt.Errorf("--output path must differ from --seen")
-	}
-	if strings.TrimSpace(opts.NewOut) != "" && pathsEqual(opts.NewOut, opts.Seen) {
-		return fmt.Errorf("--new-out path must differ from --seen")
-	}
-
 	if strings.TrimSpace(opts.NewOut) != "" {
 		if err := seen.WriteJobs(opts.NewOut, unseenJobs); err != nil {
 			return fmt.Errorf("write --new-out: %w", err)
 		}
 	}
 
-	format, err := resolveFormat(ctx, opts, outputPath)
-	if err != nil {
-		return err
-	}
-
 	writer := ctx.Out
-	var file *os.File
+	var file *fsutil.File
 	if outputPath != "" {
-		file, err = os.Create(outputPath)
+		// The previous output stays in place until the new one is complete.
+		file, err = fsutil.Create(outputPath, 0o644)
 		if err != nil {
 			return err
 		}
@@ -206,15 +327,26 @@ func runSearch(ctx *Context, query string, sitesArg string, opts SearchOptions,
 		linkStyle = export.LinkStyleFull
 	}
 	if err := export.WriteJobs(writer, outputJobs, format, export.WriteOptions{
-		ColorEnabled: colorEnabled,
-		Hyperlinks:   hyperlinks,
-		LinkStyle:    linkStyle,
+		ColorEnabled:      colorEnabled,
+		Hyperlinks:        hyperlinks,
+		LinkStyle:         linkStyle,
+		DescriptionFormat: export.DescriptionFormat(opts.DescriptionFormat),
+		IsNew:             isNew,
+		FeedTitle:         "jobcli: " + strings.Join(queries, ", "),
+		Columns:           layout.columns,
+		Template:          layout.template,
+		GroupBy:           layout.groupBy,
 	}); err != nil {
 		return err
 	}
+	if file != nil {
+		if err := file.Commit(); err != nil {
+			return err
+		}
+	}
 
 	if opts.SeenUpdate && strings.TrimSpace(opts.Seen) != "" {
-		if err := updateSeenHistory(opts.Seen, unseenJobs); err != nil {
+		if err := updateSeenHistory(opts.Seen, observedJobs, seenKey, opts.SeenBackups); err != nil {
 			return err
 		}
 	}
@@ -223,11 +355,112 @@ func runSearch(ctx *Context, query string, sitesArg string, opts SearchOptions,
 	if strings.TrimSpace(opts.Seen) != "" {
 		summaryJobs = unseenJobs
 	}
-	printSearchSummary(ctx, summaryJobs)
+	printSearchSummary(ctx, summaryJobs, queries, dedupeStats, filterStats, seenStats)
 
 	return nil
 }
 
+// parseMinSalary converts --min-salary into an annual amount and optional currency.
+func parseMinSalary(value string) (float64, string, error) {
+	if strings.TrimSpace(value) == "" {
+		return 0, "", nil
+	}
+	details, ok := salary.Parse(value)
+	if !ok {
+		return 0, "", fmt.Errorf("invalid --min-salary %q: expected an amount such as 60000 or \"60k EUR\"", value)
+	}
+	min, max := details.Annual()
+	if min <= 0 {
+		min = max
+	}
+	return min, details.Currency, nil
+}
+
+// parseRadius resolves --radius and the --location it is measured from.
+func parseRadius(value string, searchLocation string, country string) (location.Place, float64, error) {
+	if strings.TrimSpace(value) == "" {
+		return location.Place{}, 0, nil
+	}
+	radiusKm, err := location.ParseDistance(value)
+	if err != nil {
+		return location.Place{}, 0, fmt.Errorf("invalid --radius: %w", err)
+	}
+	origin, ok := location.Parse(searchLocation, country)
+	if !ok || !origin.HasCoordinates() {
+		return location.Place{}, 0, fmt.Errorf("--radius requires a --location city from the offline gazetteer, got %q", searchLocation)
+	}
+	return origin, radiusKm, nil
+}
+
+// parseArrangementList splits --arrangement into work arrangements; --remote adds "remote".
+func parseArrangementList(value string, remote bool) ([]string, error) {
+	var arrangements []string
+	add := func(arrangement string) {
+		for _, existing := range arrangements {
+			if existing == arrangement {
+				return
+			}
+		}
+		arrangements = append(arrangements, arrangement)
+	}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		arrangement := normalize.ParseWorkArrangement(part)
+		if arrangement == "" {
+			return nil, fmt.Errorf("invalid --arrangement %q: expected one of %s", part, strings.Join(normalize.WorkArrangements, ", "))
+		}
+		add(arrangement)
+	}
+	if remote {
+		add(models.WorkArrangementRemote)
+	}
+	return arrangements, nil
+}
+
+// parseSeniorityList splits a comma-separated seniority flag into normalized levels.
+func parseSeniorityList(flag string, value string) ([]string, error) {
+	var levels []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		level := normalize.ParseSeniority(part)
+		if level == "" {
+			return nil, fmt.Errorf("invalid %s %q: expected one of %s", flag, part, strings.Join(normalize.Seniorities, ", "))
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// parseExpr parses an --include or --exclude expression; empty means unset.
+func parseExpr(flag string, value string) (*filter.Expr, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	expr, err := filter.ParseExpr(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", flag, value, err)
+	}
+	return expr, nil
+}
+
+// loadCompanyList reads companies.json when a company flag is set.
+func loadCompanyList(opts SearchOptions) (company.List, error) {
+	if strings.TrimSpace(opts.ExcludeCompanies+opts.OnlyCompanies+opts.BoostCompanies) == "" {
+		return company.List{}, nil
+	}
+	entries, err := config.LoadCompanies()
+	if err != nil {
+		return company.List{}, fmt.Errorf("read companies list: %w", err)
+	}
+	return company.NewList(entries), nil
+}
+
 func pathsEqual(a, b string) bool {
 	if strings.TrimSpace(a) == "" || strings.TrimSpace(b) == "" {
 		return false
@@ -240,25 +473,68 @@ func pathsEqual(a, b string) bool {
 	return filepath.Clean(a) == filepath.Clean(b)
 }
 
-func updateSeenHistory(seenPath string, inputJobs []models.Job) error {
-	seenJobs, err := seen.ReadJobsAllowMissing(seenPath)
+// updateSeenHistory merges inputJobs into the seen file using seenKey, or the
+// strategy recorded in the file when seenKey is empty, and records the
+// strategy used. Jobs already in the file get their last_seen refreshed. The
+// file is re-read under the seen lock so runs sharing it do not drop each
+// other's jobs.
+func updateSeenHistory(seenPath string, inputJobs []models.Job, seenKey seen.KeyStrategy, backups int) error {
+	lock, err := lockSeen(seenPath)
 	if err != nil {
-		return fmt.Errorf("read --seen: %w", err)
+		return err
 	}
+	defer lock.Unlock()
 
-	mergedJobs, _ := seen.Merge(seenJobs, inputJobs)
-	if err := seen.WriteJobs(seenPath, mergedJobs); err != nil {
-		return fmt.Errorf("write --seen: %w", err)
+	if _, err := updateSeenFile(seenPath, seen.History{Jobs: inputJobs}, seenKey, backups); err != nil {
+		return fmt.Errorf("update --seen: %w", err)
 	}
-
 	return nil
 }
 
-func printSearchSummary(ctx *Context, jobs []models.Job) {
+// newJobMatcher reports whether a job has the key of one of unseenJobs.
+func newJobMatcher(unseenJobs []models.Job, strategy seen.KeyStrategy) func(models.Job) bool {
+	keys := make(map[string]struct{}, len(unseenJobs))
+	for _, job := range unseenJobs {
+		if key, ok := strategy.Key(job); ok {
+			keys[key] = struct{}{}
+		}
+	}
+	return func(job models.Job) bool {
+		key, ok := strategy.Key(job)
+		if !ok {
+			return false
+		}
+		_, exists := keys[key]
+		return exists
+	}
+}
+
+// seenRunStats counts seen-history annotations applied to a search run.
+type seenRunStats struct {
+	Reposted int
+	Ignored  int
+}
+
+func printSearchSummary(ctx *Context, jobs []models.Job, queries []string, dedupeStats dedupe.Stats, filterStats filter.Stats, seenStats seenRunStats) {
 	if ctx == nil || ctx.Err == nil {
 		return
 	}
 	_, _ = fmt.Fprintf(ctx.Err, "\n%s\n", formatSearchSummary(jobs))
+	if len(queries) > 1 {
+		_, _ = fmt.Fprintln(ctx.Err, formatQuerySummary(jobs, queries))
+	}
+	if dedupeStats.Merged > 0 {
+		_, _ = fmt.Fprintf(ctx.Err, "deduplicated: merged=%d clusters=%d\n", dedupeStats.Merged, dedupeStats.Clusters)
+	}
+	if filterStats.DroppedTotal() > 0 {
+		_, _ = fmt.Fprintln(ctx.Err, formatFilterSummary(filterStats))
+	}
+	if seenStats.Reposted > 0 {
+		_, _ = fmt.Fprintf(ctx.Err, "reposted: jobs=%d\n", seenStats.Reposted)
+	}
+	if seenStats.Ignored > 0 {
+		_, _ = fmt.Fprintf(ctx.Err, "ignored: jobs=%d\n", seenStats.Ignored)
+	}
 }
 
 func formatSearchSummary(jobs []models.Job) string {
@@ -275,6 +551,36 @@ func formatSearchSummary(jobs []models.Job) string {
 	return fmt.Sprintf("summary: new_jobs=%d by_site=%s", len(jobs), strings.Join(parts, ", "))
 }
 
+// formatQuerySummary counts jobs per query in run order. A job returned by
+// several queries counts for each of them.
+func formatQuerySummary(jobs []models.Job, queries []string) string {
+	totals := make(map[string]int, len(queries))
+	for _, job := range jobs {
+		for _, query := range job.Queries {
+			totals[query]++
+		}
+	}
+	parts := make([]string, 0, len(queries))
+	for _, query := range queries {
+		parts = append(parts, fmt.Sprintf("%s:%d", query, totals[query]))
+	}
+	return "queries: by_query=" + strings.Join(parts, ", ")
+}
+
+func formatFilterSummary(stats filter.Stats) string {
+	reasons := stats.Reasons()
+	if len(reasons) == 0 {
+		return "filtered: dropped=0 by_reason=none"
+	}
+
+	parts := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		parts = append(parts, fmt.Sprintf("%s:%d", reason.Reason, reason.Count))
+	}
+
+	return fmt.Sprintf("filtered: dropped=%d by_reason=%s", stats.DroppedTotal(), strings.Join(parts, ", "))
+}
+
 type siteCount struct {
 	site  string
 	total int
@@ -308,22 +614,42 @@ type queryFileConfig struct {
 }
 
 type queryFileSearchOptions struct {
-	Location   *string `json:"location"`
-	Country    *string `json:"country"`
-	Sites      *string `json:"sites"`
-	Limit      *int    `json:"limit"`
-	Offset     *int    `json:"offset"`
-	Remote     *bool   `json:"remote"`
-	JobType    *string `json:"job_type"`
-	Hours      *int    `json:"hours"`
-	Format     *string `json:"format"`
-	Links      *string `json:"links"`
-	Output     *string `json:"output"`
-	Proxies    *string `json:"proxies"`
-	Seen       *string `json:"seen"`
-	NewOnly    *bool   `json:"new_only"`
-	NewOut     *string `json:"new_out"`
-	SeenUpdate *bool   `json:"seen_update"`
+	Location          *string `json:"location"`
+	Country           *string `json:"country"`
+	Sites             *string `json:"sites"`
+	Limit             *int    `json:"limit"`
+	Offset            *int    `json:"offset"`
+	Remote            *bool   `json:"remote"`
+	Arrangement       *string `json:"arrangement"`
+	JobType           *string `json:"job_type"`
+	Hours             *int    `json:"hours"`
+	MinSalary         *string `json:"min_salary"`
+	Seniority         *string `json:"seniority"`
+	ExcludeSeniority  *string `json:"exclude_seniority"`
+	Include           *string `json:"include"`
+	Exclude           *string `json:"exclude"`
+	ExcludeCompanies  *string `json:"exclude_companies"`
+	OnlyCompanies     *string `json:"only_companies"`
+	BoostCompanies    *string `json:"boost_companies"`
+	ExcludeAgencies   *bool   `json:"exclude_agencies"`
+	Radius            *string `json:"radius"`
+	StrictFilters     *bool   `json:"strict_filters"`
+	Format            *string `json:"format"`
+	Columns           *string `json:"columns"`
+	Template          *string `json:"template"`
+	Sort              *string `json:"sort"`
+	Desc              *bool   `json:"desc"`
+	GroupBy           *string `json:"group_by"`
+	Links             *string `json:"links"`
+	DescriptionFormat *string `json:"description_format"`
+	Output            *string `json:"output"`
+	Proxies           *string `json:"proxies"`
+	Seen              *string `json:"seen"`
+	NewOnly           *bool   `json:"new_only"`
+	NewOut            *string `json:"new_out"`
+	SeenUpdate        *bool   `json:"seen_update"`
+	SeenKey           *string `json:"seen_key"`
+	SeenBackups       *int    `json:"seen_backups"`
 }
 
 type queryFileGlobalOptions struct {
@@ -353,18 +679,72 @@ func applyQueryFileDefaults(ctx *Context, opts SearchOptions, sitesArg string, f
 	if fileCfg.Search.Remote != nil && !cliProvided("--remote") {
 		opts.Remote = *fileCfg.Search.Remote
 	}
+	if fileCfg.Search.Arrangement != nil && !cliProvided("--arrangement") {
+		opts.Arrangement = strings.TrimSpace(*fileCfg.Search.Arrangement)
+	}
 	if fileCfg.Search.JobType != nil && !cliProvided("--job-type") {
 		opts.JobType = strings.ToLower(strings.TrimSpace(*fileCfg.Search.JobType))
 	}
 	if fileCfg.Search.Hours != nil && !cliProvided("--hours") {
 		opts.Hours = *fileCfg.Search.Hours
 	}
+	if fileCfg.Search.MinSalary != nil && !cliProvided("--min-salary") {
+		opts.MinSalary = strings.TrimSpace(*fileCfg.Search.MinSalary)
+	}
+	if fileCfg.Search.Seniority != nil && !cliProvided("--seniority") {
+		opts.Seniority = strings.TrimSpace(*fileCfg.Search.Seniority)
+	}
+	if fileCfg.Search.ExcludeSeniority != nil && !cliProvided("--exclude-seniority") {
+		opts.ExcludeSeniority = strings.TrimSpace(*fileCfg.Search.ExcludeSeniority)
+	}
+	if fileCfg.Search.Include != nil && !cliProvided("--include") {
+		opts.Include = strings.TrimSpace(*fileCfg.Search.Include)
+	}
+	if fileCfg.Search.Exclude != nil && !cliProvided("--exclude") {
+		opts.Exclude = strings.TrimSpace(*fileCfg.Search.Exclude)
+	}
+	if fileCfg.Search.ExcludeCompanies != nil && !cliProvided("--exclude-companies") {
+		opts.ExcludeCompanies = strings.TrimSpace(*fileCfg.Search.ExcludeCompanies)
+	}
+	if fileCfg.Search.OnlyCompanies != nil && !cliProvided("--only-companies") {
+		opts.OnlyCompanies = strings.TrimSpace(*fileCfg.Search.OnlyCompanies)
+	}
+	if fileCfg.Search.BoostCompanies != nil && !cliProvided("--boost-companies") {
+		opts.BoostCompanies = strings.TrimSpace(*fileCfg.Search.BoostCompanies)
+	}
+	if fileCfg.Search.ExcludeAgencies != nil && !cliProvided("--exclude-agencies") {
+		opts.ExcludeAgencies = *fileCfg.Search.ExcludeAgencies
+	}
+	if fileCfg.Search.Radius != nil && !cliProvided("--radius") {
+		opts.Radius = strings.TrimSpace(*fileCfg.Search.Radius)
+	}
+	if fileCfg.Search.StrictFilters != nil && !cliProvided("--strict-filters") {
+		opts.StrictFilters = *fileCfg.Search.StrictFilters
+	}
 	if fileCfg.Search.Format != nil && !cliProvided("--format") {
 		opts.Format = strings.ToLower(strings.TrimSpace(*fileCfg.Search.Format))
 	}
+	if fileCfg.Search.Columns != nil && !cliProvided("--columns") {
+		opts.Columns = strings.TrimSpace(*fileCfg.Search.Columns)
+	}
+	if fileCfg.Search.Template != nil && !cliProvided("--template") {
+		opts.Template = strings.TrimSpace(*fileCfg.Search.Template)
+	}
+	if fileCfg.Search.Sort != nil && !cliProvided("--sort") {
+		opts.Sort = strings.ToLower(strings.TrimSpace(*fileCfg.Search.Sort))
+	}
+	if fileCfg.Search.Desc != nil && !cliProvided("--desc") {
+		opts.Desc = *fileCfg.Search.Desc
+	}
+	if fileCfg.Search.GroupBy != nil && !cliProvided("--group-by") {
+		opts.GroupBy = strings.ToLower(strings.TrimSpace(*fileCfg.Search.GroupBy))
+	}
 	if fileCfg.Search.Links != nil && !cliProvided("--links") {
 		opts.Links = strings.ToLower(strings.TrimSpace(*fileCfg.Search.Links))
 	}
+	if fileCfg.Search.DescriptionFormat != nil && !cliProvided("--description-format") {
+		opts.DescriptionFormat = strings.ToLower(strings.TrimSpace(*fileCfg.Search.DescriptionFormat))
+	}
 	if fileCfg.Search.Proxies != nil && !cliProvided("--proxies") {
 		opts.Proxies = *fileCfg.Search.Proxies
 	}
@@ -380,6 +760,12 @@ func applyQueryFileDefaults(ctx *Context, opts SearchOptions, sitesArg string, f
 	if fileCfg.Search.SeenUpdate != nil && !cliProvided("--seen-update") {
 		opts.SeenUpdate = *fileCfg.Search.SeenUpdate
 	}
+	if fileCfg.Search.SeenKey != nil && !cliProvided("--seen-key") {
+		opts.SeenKey = strings.ToLower(strings.TrimSpace(*fileCfg.Search.SeenKey))
+	}
+	if fileCfg.Search.SeenBackups != nil && !cliProvided("--seen-backups") {
+		opts.SeenBackups = *fileCfg.Search.SeenBackups
+	}
 	if fileCfg.Search.Output != nil && !cliProvided("--output", "--out", "--file", "-o") {
 		opts.Output = *fileCfg.Search.Output
 		opts.Out = ""
@@ -569,9 +955,27 @@ func validateQueryFileConfig(path string, cfg queryFileConfig) error {
 	if cfg.Search.Format != nil {
 		format := strings.ToLower(strings.TrimSpace(*cfg.Search.Format))
 		switch format {
-		case "", "csv", "json", "md":
+		case "", "csv", "json", "md", "ndjson", "html", "rss", "atom", "xlsx", "ods":
 		default:
-			return fmt.Errorf("invalid --query-file %q: field \"search_options.format\" must be one of csv, json, md, or empty", path)
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.format\" must be one of csv, json, md, ndjson, html, rss, atom, xlsx, ods, or empty", path)
+		}
+	}
+
+	if cfg.Search.Columns != nil {
+		if _, err := export.ParseColumns(*cfg.Search.Columns); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.columns\" must be a comma-separated list of %s", path, strings.Join(export.Columns(), ", "))
+		}
+	}
+
+	if cfg.Search.Sort != nil {
+		if _, err := rank.ParseKey(*cfg.Search.Sort); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.sort\" must be one of posted_at, site, company, title, salary, score, or empty", path)
+		}
+	}
+
+	if cfg.Search.GroupBy != nil {
+		if _, err := export.ParseGroupBy(*cfg.Search.GroupBy); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.group_by\" must be one of site, company, query, or empty", path)
 		}
 	}
 
@@ -582,6 +986,67 @@ func validateQueryFileConfig(path string, cfg queryFileConfig) error {
 		}
 	}
 
+	if cfg.Search.MinSalary != nil {
+		if _, _, err := parseMinSalary(*cfg.Search.MinSalary); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.min_salary\" must be an amount such as 60000 or \"60k EUR\"", path)
+		}
+	}
+
+	if cfg.Search.Radius != nil && strings.TrimSpace(*cfg.Search.Radius) != "" {
+		if _, err := location.ParseDistance(*cfg.Search.Radius); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.radius\" must be a distance such as \"50km\" or \"30mi\"", path)
+		}
+	}
+
+	if cfg.Search.Arrangement != nil {
+		if _, err := parseArrangementList(*cfg.Search.Arrangement, false); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.arrangement\" must be a comma-separated list of %s", path, strings.Join(normalize.WorkArrangements, ", "))
+		}
+	}
+
+	if cfg.Search.Seniority != nil {
+		if _, err := parseSeniorityList("--seniority", *cfg.Search.Seniority); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.seniority\" must be a comma-separated list of %s", path, strings.Join(normalize.Seniorities, ", "))
+		}
+	}
+
+	if cfg.Search.ExcludeSeniority != nil {
+		if _, err := parseSeniorityList("--exclude-seniority", *cfg.Search.ExcludeSeniority); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.exclude_seniority\" must be a comma-separated list of %s", path, strings.Join(normalize.Seniorities, ", "))
+		}
+	}
+
+	if cfg.Search.Include != nil && strings.TrimSpace(*cfg.Search.Include) != "" {
+		if _, err := filter.ParseExpr(*cfg.Search.Include); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.include\" is not a valid expression: %w", path, err)
+		}
+	}
+
+	if cfg.Search.Exclude != nil && strings.TrimSpace(*cfg.Search.Exclude) != "" {
+		if _, err := filter.ParseExpr(*cfg.Search.Exclude); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.exclude\" is not a valid expression: %w", path, err)
+		}
+	}
+
+	if cfg.Search.SeenKey != nil {
+		if _, err := seen.ParseKeyStrategy(*cfg.Search.SeenKey); err != nil {
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.seen_key\" must be one of %s", path, seenKeyNames())
+		}
+	}
+
+	if cfg.Search.SeenBackups != nil && *cfg.Search.SeenBackups < 0 {
+		return fmt.Errorf("invalid --query-file %q: field \"search_options.seen_backups\" must be >= 0", path)
+	}
+
+	if cfg.Search.DescriptionFormat != nil {
+		descriptionFormat := strings.ToLower(strings.TrimSpace(*cfg.Search.DescriptionFormat))
+		switch descriptionFormat {
+		case "plain", "markdown", "html":
+		default:
+			return fmt.Errorf("invalid --query-file %q: field \"search_options.description_format\" must be one of plain, markdown, or html", path)
+		}
+	}
+
 	if cfg.Global.Color != nil {
 		color := strings.ToLower(strings.TrimSpace(*cfg.Global.Color))
 		switch color {
@@ -683,36 +1148,74 @@ func parseStringArray(values []any, path string, fieldName string) ([]string, er
 	return queries, nil
 }
 
+// searchJobs runs every query and merges the results: a site returning a job
+// again for a later query only adds that query to it, and postings of one job
+// on several sites are clustered by dedupe.Merge.
+func searchJobs(scrapers []scraper.Scraper, params models.SearchParams, queries []string) ([]models.Job, []scraperFailure, dedupe.Stats, error) {
+	var (
+		jobs     []models.Job
+		failures []scraperFailure
+	)
+	for _, query := range queries {
+		queryJobs, queryFailures, err := runScrapersForQuery(scrapers, params, query)
+		if err != nil {
+			return nil, nil, dedupe.Stats{}, err
+		}
+		queryJobs = limitJobs(queryJobs, params.Limit)
+		jobs = mergeUniqueJobs(jobs, queryJobs)
+		failures = append(failures, queryFailures...)
+	}
+	jobs, stats := dedupe.Merge(jobs)
+	return jobs, failures, stats, nil
+}
+
 func runScrapersForQuery(scrapers []scraper.Scraper, base models.SearchParams, query string) ([]models.Job, []scraperFailure, error) {
 	params := base
 	params.Query = query
-	return runScrapers(scrapers, params)
+	jobs, failures, err := runScrapers(scrapers, params)
+	tagQuery(jobs, query)
+	return jobs, failures, err
+}
+
+// tagQuery records query, and the site that returned it, on each job.
+func tagQuery(jobs []models.Job, query string) {
+	for i := range jobs {
+		jobs[i].Queries = []string{query}
+		jobs[i].QueryHits = []models.QueryHit{{Query: query, Site: jobs[i].Site}}
+	}
 }
 
+// mergeUniqueJobs appends incoming to existing, dropping postings a site
+// already returned for an earlier query. Postings of the same job on other
+// sites are kept for dedupe.Merge, which lists them in Job.Sources.
 func mergeUniqueJobs(existing []models.Job, incoming []models.Job) []models.Job {
 	if len(incoming) == 0 {
 		return existing
 	}
 
-	keys := make(map[string]struct{}, len(existing)+len(incoming))
+	keys := make(map[string]int, len(existing)+len(incoming))
 	merged := make([]models.Job, 0, len(existing)+len(incoming))
 
 	for _, job := range existing {
 		merged = append(merged, job)
-		key, ok := seen.Key(job)
+		key, ok := siteJobKey(job)
 		if !ok {
 			continue
 		}
-		keys[key] = struct{}{}
+		if _, exists := keys[key]; !exists {
+			keys[key] = len(merged) - 1
+		}
 	}
 
 	for _, job := range incoming {
-		key, ok := seen.Key(job)
+		key, ok := siteJobKey(job)
 		if !ok {
 			merged = append(merged, job)
 			continue
 		}
-		if _, exists := keys[key]; exists {
+		if index, exists := keys[key]; exists {
+			// Found again by a later query: keep the first job and note the query.
+			dedupe.AddQueries(&merged[index], job)
 			continue
 		}
 		merged = append(merged, job)
@@ -721,6 +1224,15 @@ func mergeUniqueJobs(existing []models.Job, incoming []models.Job) []models.Job
 	return merged
 }
 
+// siteJobKey is seen.Key scoped to the job's site.
+func siteJobKey(job models.Job) (string, bool) {
+	key, ok := seen.Key(job)
+	if !ok {
+		return "", false
+	}
+	return seen.Normalize(job.Site) + "|" + key, true
+}
+
 func limitJobs(jobs []models.Job, limit int) []models.Job {
 	if limit <= 0 || len(jobs) <= limit {
 		return jobs
@@ -729,45 +1241,51 @@ func limitJobs(jobs []models.Job, limit int) []models.Job {
 }
 
 func runScrapers(scrapers []scraper.Scraper, params models.SearchParams) ([]models.Job, []scraperFailure, error) {
-	var (
-		wg      sync.WaitGroup
-		results = make(chan scraperResult, len(scrapers))
-	)
-
-	for _, sc := range scrapers {
-		wg.Add(1)
-		go func(sc scraper.Scraper) {
-			defer wg.Done()
-			jobs, err := sc.Search(context.Background(), params)
-			results <- scraperResult{site: sc.Name(), jobs: jobs, err: err}
-		}(sc)
-	}
-
-	wg.Wait()
-	close(results)
-
 	var (
 		all      []models.Job
 		failures []scraperFailure
 	)
-	for res := range results {
+	streamScrapers(scrapers, params, func(res scraperResult) {
 		if res.err != nil {
-			failures = append(failures, scraperFailure{
-				site:           res.site,
-				err:            res.err,
-				notImplemented: errors.Is(res.err, scraper.ErrNotImplemented),
-			})
-			continue
+			failures = append(failures, newScraperFailure(res))
+			return
 		}
 		all = append(all, res.jobs...)
-	}
+	})
 
+	enrichJobs(all, params)
 	sortJobsBySite(all)
 	sortScraperFailures(failures)
 
 	return all, failures, nil
 }
 
+// streamScrapers runs scrapers concurrently and passes each result to handle
+// as soon as that scraper finishes. handle is called from the calling
+// goroutine, one result at a time.
+func streamScrapers(scrapers []scraper.Scraper, params models.SearchParams, handle func(scraperResult)) {
+	results := make(chan scraperResult, len(scrapers))
+	for _, sc := range scrapers {
+		go func(sc scraper.Scraper) {
+			jobs, err := sc.Search(context.Background(), params)
+			results <- scraperResult{site: sc.Name(), jobs: jobs, err: err}
+		}(sc)
+	}
+	for range scrapers {
+		handle(<-results)
+	}
+}
+
+// enrichJobs derives structured fields from the raw values scrapers return.
+func enrichJobs(jobs []models.Job, params models.SearchParams) {
+	for i := range jobs {
+		salary.Apply(&jobs[i])
+		normalize.Apply(&jobs[i])
+		agency.Apply(&jobs[i])
+		location.Apply(&jobs[i], params.Country)
+	}
+}
+
 func sortJobsBySite(jobs []models.Job) {
 	sort.SliceStable(jobs, func(i, j int) bool {
 		return strings.ToLower(jobs[i].Site) < strings.ToLower(jobs[j].Site)
@@ -792,6 +1310,14 @@ type scraperFailure struct {
 	notImplemented bool
 }
 
+func newScraperFailure(res scraperResult) scraperFailure {
+	return scraperFailure{
+		site:           res.site,
+		err:            res.err,
+		notImplemented: errors.Is(res.err, scraper.ErrNotImplemented),
+	}
+}
+
 func reportScraperFailures(ctx *Context, failures []scraperFailure) {
 	if ctx == nil || ctx.UI == nil {
 		return
@@ -841,7 +1367,11 @@ func resolveFormat(ctx *Context, opts SearchOptions, outputPath string) (export.
 		return export.FormatTSV, nil
 	}
 	if opts.Format != "" {
-		return parseFormat(opts.Format)
+		format, err := parseFormat(opts.Format)
+		if err == nil && export.IsBinary(format) && isTTY(ctx.Out) {
+			return "", fmt.Errorf("--format %s writes a binary file: use --output or redirect stdout", format)
+		}
+		return format, err
 	}
 	if isTTY(ctx.Out) {
 		return export.FormatTable, nil
@@ -849,12 +1379,72 @@ func resolveFormat(ctx *Context, opts SearchOptions, outputPath string) (export.
 	return export.FormatCSV, nil
 }
 
+// outputLayout holds the options that shape export output beyond its format.
+type outputLayout struct {
+	columns  []string
+	template *template.Template
+	groupBy  export.GroupBy
+}
+
+// resolveLayout parses --columns, --template and --group-by, which only apply
+// to some formats and exclude each other.
+func resolveLayout(opts SearchOptions, format export.Format) (outputLayout, error) {
+	var layout outputLayout
+	columns, err := export.ParseColumns(opts.Columns)
+	if err != nil {
+		return layout, fmt.Errorf("invalid --columns: %w", err)
+	}
+	groupBy, err := export.ParseGroupBy(opts.GroupBy)
+	if err != nil {
+		return layout, fmt.Errorf("invalid --group-by: %w", err)
+	}
+	if strings.TrimSpace(opts.Template) != "" {
+		if opts.Format != "" {
+			return layout, fmt.Errorf("--template cannot be combined with --format")
+		}
+		if len(columns) > 0 || groupBy != export.GroupNone {
+			return layout, fmt.Errorf("--template cannot be combined with --columns or --group-by")
+		}
+		tmpl, err := export.ParseTemplate(opts.Template)
+		if err != nil {
+			return layout, fmt.Errorf("read --template: %w", err)
+		}
+		layout.template = tmpl
+		return layout, nil
+	}
+	switch format {
+	case export.FormatTable, export.FormatCSV, export.FormatTSV:
+	default:
+		if len(columns) > 0 {
+			return layout, fmt.Errorf("--columns applies only to table, csv and tsv output, not %s", format)
+		}
+	}
+	if groupBy != export.GroupNone && format != export.FormatTable && format != export.FormatMarkdown {
+		return layout, fmt.Errorf("--group-by applies only to table and md output, not %s", format)
+	}
+	layout.columns = columns
+	layout.groupBy = groupBy
+	return layout, nil
+}
+
 func parseFormat(value string) (export.Format, error) {
 	switch strings.ToLower(strings.TrimSpace(value)) {
 	case "csv":
 		return export.FormatCSV, nil
 	case "json":
 		return export.FormatJSON, nil
+	case "ndjson", "jsonl":
+		return export.FormatNDJSON, nil
+	case "html":
+		return export.FormatHTML, nil
+	case "rss":
+		return export.FormatRSS, nil
+	case "atom":
+		return export.FormatAtom, nil
+	case "xlsx":
+		return export.FormatXLSX, nil
+	case "ods":
+		return export.FormatODS, nil
 	case "md", "markdown":
 		return export.FormatMarkdown, nil
 	case "tsv":
@@ -963,3 +1553,11 @@ func startSearchIndicator(ctx *Context) func() {
 		<-stopped
 	}
 }
+
+func seenKeyNames() string {
+	names := make([]string, len(seen.KeyStrategies))
+	for i, strategy := range seen.KeyStrategies {
+		names[i] = string(strategy)
+	}
+	return strings.Join(names, ", ")
+}
@@ -5,10 +5,13 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/jimezsa/jobcli/internal/dedupe"
 	"github.com/jimezsa/jobcli/internal/export"
+	"github.com/jimezsa/jobcli/internal/filter"
 	"github.com/jimezsa/jobcli/internal/models"
 	"github.com/jimezsa/jobcli/internal/seen"
 )
@@ -33,6 +36,40 @@ func TestResolveFormatWithOutputPathRespectsGlobalFlags(t *testing.T) {
 	}
 }
 
+func TestResolveLayout(t *testing.T) {
+	layout, err := resolveLayout(SearchOptions{Columns: "site, Title,posted_at"}, export.FormatCSV)
+	if err != nil || layout.template != nil {
+		t.Fatalf("resolveLayout() = %+v, %v", layout, err)
+	}
+	if strings.Join(layout.columns, ",") != "site,title,posted_at" {
+		t.Fatalf("columns = %v, want site,title,posted_at", layout.columns)
+	}
+
+	if _, err := resolveLayout(SearchOptions{Columns: "site,colour"}, export.FormatCSV); err == nil || !strings.Contains(err.Error(), `unknown column "colour"`) {
+		t.Fatalf("resolveLayout(unknown column) error = %v", err)
+	}
+	if _, err := resolveLayout(SearchOptions{Columns: "site"}, export.FormatJSON); err == nil {
+		t.Fatalf("resolveLayout(--columns with json) error = nil, want error")
+	}
+	if layout, err := resolveLayout(SearchOptions{GroupBy: "company"}, export.FormatMarkdown); err != nil || layout.groupBy != export.GroupCompany {
+		t.Fatalf("resolveLayout(--group-by company) = %+v, %v", layout, err)
+	}
+	if _, err := resolveLayout(SearchOptions{GroupBy: "site"}, export.FormatCSV); err == nil {
+		t.Fatalf("resolveLayout(--group-by with csv) error = nil, want error")
+	}
+
+	path := filepath.Join(t.TempDir(), "jobs.tmpl")
+	if err := os.WriteFile(path, []byte("{{range .}}{{.Title}}\n{{end}}"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if layout, err := resolveLayout(SearchOptions{Template: path}, export.FormatTable); err != nil || layout.template == nil {
+		t.Fatalf("resolveLayout(--template) = %+v, %v", layout, err)
+	}
+	if _, err := resolveLayout(SearchOptions{Template: path, Format: "csv"}, export.FormatCSV); err == nil {
+		t.Fatalf("resolveLayout(--template with --format) error = nil, want error")
+	}
+}
+
 func TestUpdateSeenHistoryCreatesFileAndMerges(t *testing.T) {
 	dir := t.TempDir()
 	seenPath := filepath.Join(dir, "jobs_seen.json")
@@ -41,7 +78,7 @@ func TestUpdateSeenHistoryCreatesFileAndMerges(t *testing.T) {
 		{Site: "test", Title: "Hardware Engineer", Company: "Acme", URL: "https://example.com/1"},
 	}
 
-	if err := updateSeenHistory(seenPath, input); err != nil {
+	if err := updateSeenHistory(seenPath, input, "", 0); err != nil {
 		t.Fatalf("updateSeenHistory() error = %v", err)
 	}
 
@@ -54,7 +91,7 @@ func TestUpdateSeenHistoryCreatesFileAndMerges(t *testing.T) {
 	}
 
 	// Calling it again with the same job should be idempotent.
-	if err := updateSeenHistory(seenPath, input); err != nil {
+	if err := updateSeenHistory(seenPath, input, "", 0); err != nil {
 		t.Fatalf("updateSeenHistory() (2nd) error = %v", err)
 	}
 	got, err = seen.ReadJobs(seenPath)
@@ -70,7 +107,7 @@ func TestUpdateSeenHistoryCreatesFileAndMerges(t *testing.T) {
 		{Site: "test", Title: "Hardware Engineer", Company: "Acme", URL: "https://example.com/1"},
 		{Site: "test", Title: "Embedded Engineer", Company: "Beta", URL: "https://example.com/2"},
 	}
-	if err := updateSeenHistory(seenPath, input2); err != nil {
+	if err := updateSeenHistory(seenPath, input2, "", 0); err != nil {
 		t.Fatalf("updateSeenHistory() (3rd) error = %v", err)
 	}
 	got, err = seen.ReadJobs(seenPath)
@@ -82,6 +119,31 @@ func TestUpdateSeenHistoryCreatesFileAndMerges(t *testing.T) {
 	}
 }
 
+func TestUpdateSeenHistoryRecordsKeyStrategy(t *testing.T) {
+	seenPath := filepath.Join(t.TempDir(), "jobs_seen.json")
+	munich := models.Job{Site: "test", Title: "Hardware Engineer", Company: "Acme", City: "Munich", CountryCode: "DE"}
+	berlin := models.Job{Site: "test", Title: "Hardware Engineer", Company: "Acme", City: "Berlin", CountryCode: "DE"}
+
+	if err := updateSeenHistory(seenPath, []models.Job{munich}, seen.KeyTitleCompanyLocation, 0); err != nil {
+		t.Fatalf("updateSeenHistory() error = %v", err)
+	}
+	// Without --seen-key the recorded strategy keeps the two offices apart.
+	if err := updateSeenHistory(seenPath, []models.Job{berlin}, "", 0); err != nil {
+		t.Fatalf("updateSeenHistory() (2nd) error = %v", err)
+	}
+
+	history, err := seen.ReadHistory(seenPath)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if history.KeyStrategy != seen.KeyTitleCompanyLocation {
+		t.Fatalf("KeyStrategy = %q, want %q", history.KeyStrategy, seen.KeyTitleCompanyLocation)
+	}
+	if len(history.Jobs) != 2 {
+		t.Fatalf("len(history.Jobs) = %d, want 2", len(history.Jobs))
+	}
+}
+
 func TestParseQueries(t *testing.T) {
 	t.Run("single query", func(t *testing.T) {
 		got, err := parseQueries("software engineer")
@@ -540,6 +602,91 @@ func TestLoadQueryFileConfigValidation(t *testing.T) {
 			t.Fatalf("loadQueryFileConfig() error = %q, want links validation error", err.Error())
 		}
 	})
+
+	t.Run("rejects invalid description format", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "queries.json")
+		content := `{"search_options": {"description_format":"rtf"}}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		_, err := loadQueryFileConfig(path)
+		if err == nil {
+			t.Fatalf("loadQueryFileConfig() error = nil, want error")
+		}
+		if !strings.Contains(err.Error(), "search_options.description_format") {
+			t.Fatalf("loadQueryFileConfig() error = %q, want description_format validation error", err.Error())
+		}
+	})
+
+	t.Run("rejects invalid exclude seniority", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "queries.json")
+		content := `{"search_options": {"exclude_seniority":"intern,student"}}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		_, err := loadQueryFileConfig(path)
+		if err == nil {
+			t.Fatalf("loadQueryFileConfig() error = nil, want error")
+		}
+		if !strings.Contains(err.Error(), "search_options.exclude_seniority") {
+			t.Fatalf("loadQueryFileConfig() error = %q, want exclude_seniority validation error", err.Error())
+		}
+	})
+
+	t.Run("rejects invalid exclude expression", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "queries.json")
+		content := `{"search_options": {"exclude":"title:(werkstudent OR"}}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		_, err := loadQueryFileConfig(path)
+		if err == nil {
+			t.Fatalf("loadQueryFileConfig() error = nil, want error")
+		}
+		if !strings.Contains(err.Error(), "search_options.exclude") {
+			t.Fatalf("loadQueryFileConfig() error = %q, want exclude validation error", err.Error())
+		}
+	})
+
+	t.Run("rejects unknown columns", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "queries.json")
+		content := `{"search_options": {"columns":"site,colour"}}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		_, err := loadQueryFileConfig(path)
+		if err == nil {
+			t.Fatalf("loadQueryFileConfig() error = nil, want error")
+		}
+		if !strings.Contains(err.Error(), "search_options.columns") {
+			t.Fatalf("loadQueryFileConfig() error = %q, want columns validation error", err.Error())
+		}
+	})
+
+	t.Run("rejects invalid seen key", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "queries.json")
+		content := `{"search_options": {"seen_key":"title"}}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		_, err := loadQueryFileConfig(path)
+		if err == nil {
+			t.Fatalf("loadQueryFileConfig() error = nil, want error")
+		}
+		if !strings.Contains(err.Error(), "search_options.seen_key") {
+			t.Fatalf("loadQueryFileConfig() error = %q, want seen_key validation error", err.Error())
+		}
+	})
 }
 
 func TestMergeUniqueJobsDedupesAcrossQueries(t *testing.T) {
@@ -548,15 +695,17 @@ func TestMergeUniqueJobsDedupesAcrossQueries(t *testing.T) {
 		{Site: "indeed", URL: "https://example.com/fallback"},
 	}
 	incoming := []models.Job{
-		{Site: "glassdoor", Title: " backend engineer ", Company: "ACME", URL: "https://example.com/other"},
-		{Site: "ziprecruiter", URL: "https://example.com/fallback"},
+		{Site: "linkedin", Title: " backend engineer ", Company: "ACME", URL: "https://example.com/other"},
+		{Site: "indeed", URL: "https://example.com/fallback"},
 		{Site: "linkedin", Title: "Data Engineer", Company: "Acme", URL: "https://example.com/2"},
 		{Site: "stepstone"},
+		// Other sites' postings are left for dedupe.Merge to cluster.
+		{Site: "glassdoor", Title: "Backend Engineer", Company: "Acme", URL: "https://example.com/3"},
 	}
 
 	got := mergeUniqueJobs(existing, incoming)
-	if len(got) != 4 {
-		t.Fatalf("len(got) = %d, want 4", len(got))
+	if len(got) != 5 {
+		t.Fatalf("len(got) = %d, want 5", len(got))
 	}
 	if got[0].Title != "Backend Engineer" || got[1].URL != "https://example.com/fallback" {
 		t.Fatalf("existing jobs order/values changed: %#v", got[:2])
@@ -567,6 +716,59 @@ func TestMergeUniqueJobsDedupesAcrossQueries(t *testing.T) {
 	if got[3].Site != "stepstone" {
 		t.Fatalf("expected invalid-key incoming job at index 3, got %#v", got[3])
 	}
+	if got[4].Site != "glassdoor" {
+		t.Fatalf("expected other-site duplicate at index 4, got %#v", got[4])
+	}
+}
+
+func TestMergeUniqueJobsRecordsQueries(t *testing.T) {
+	existing := []models.Job{{Site: "linkedin", Title: "Backend Engineer", Company: "Acme"}}
+	tagQuery(existing, "backend")
+	incoming := []models.Job{
+		{Site: "linkedin", Title: "Backend Engineer", Company: "ACME"},
+		{Site: "indeed", Title: "Platform Engineer", Company: "Acme"},
+	}
+	tagQuery(incoming, "platform")
+
+	got := mergeUniqueJobs(existing, incoming)
+	if strings.Join(got[0].Queries, ",") != "backend,platform" || strings.Join(got[1].Queries, ",") != "platform" {
+		t.Fatalf("queries = %v, %v; want backend,platform and platform", got[0].Queries, got[1].Queries)
+	}
+	wantHits := []models.QueryHit{{Query: "backend", Site: "linkedin"}, {Query: "platform", Site: "linkedin"}}
+	if !slices.Equal(got[0].QueryHits, wantHits) {
+		t.Fatalf("query hits = %v, want %v", got[0].QueryHits, wantHits)
+	}
+	if len(existing[0].Queries) != 1 {
+		t.Fatalf("mergeUniqueJobs() modified existing queries: %v", existing[0].Queries)
+	}
+
+	want := "queries: by_query=backend:1, platform:2, sre:0"
+	if summary := formatQuerySummary(got, []string{"backend", "platform", "sre"}); summary != want {
+		t.Fatalf("formatQuerySummary() = %q, want %q", summary, want)
+	}
+}
+
+func TestMergeUniqueJobsLeavesCrossSiteDuplicatesToDedupe(t *testing.T) {
+	queryOne := []models.Job{{Site: "linkedin", Title: "Backend Engineer", Company: "Acme", URL: "https://example.com/li"}}
+	tagQuery(queryOne, "backend")
+	queryTwo := []models.Job{{Site: "indeed", Title: "Backend Engineer", Company: "Acme", URL: "https://example.com/in"}}
+	tagQuery(queryTwo, "golang")
+
+	jobs, stats := dedupe.Merge(mergeUniqueJobs(mergeUniqueJobs(nil, queryOne), queryTwo))
+	if len(jobs) != 1 || stats.Merged != 1 {
+		t.Fatalf("Merge() = %d jobs, %+v; want one merged job", len(jobs), stats)
+	}
+	wantSources := []models.JobSource{
+		{Site: "linkedin", URL: "https://example.com/li"},
+		{Site: "indeed", URL: "https://example.com/in"},
+	}
+	if !slices.Equal(jobs[0].Sources, wantSources) {
+		t.Fatalf("sources = %v, want %v", jobs[0].Sources, wantSources)
+	}
+	wantHits := []models.QueryHit{{Query: "backend", Site: "linkedin"}, {Query: "golang", Site: "indeed"}}
+	if strings.Join(jobs[0].Queries, ",") != "backend,golang" || !slices.Equal(jobs[0].QueryHits, wantHits) {
+		t.Fatalf("queries = %v, hits = %v; want backend,golang and %v", jobs[0].Queries, jobs[0].QueryHits, wantHits)
+	}
 }
 
 func TestMergeUniqueJobsKeepsSingleQueryDuplicates(t *testing.T) {
@@ -624,6 +826,90 @@ func TestFormatSearchSummary(t *testing.T) {
 	})
 }
 
+func TestParseMinSalary(t *testing.T) {
+	amount, currency, err := parseMinSalary("60k EUR")
+	if err != nil {
+		t.Fatalf("parseMinSalary() error = %v", err)
+	}
+	if amount != 60000 || currency != "EUR" {
+		t.Fatalf("parseMinSalary() = %v %q, want 60000 EUR", amount, currency)
+	}
+
+	amount, _, err = parseMinSalary("$40 an hour")
+	if err != nil {
+		t.Fatalf("parseMinSalary() hourly error = %v", err)
+	}
+	if amount != 83200 {
+		t.Fatalf("parseMinSalary() hourly = %v, want 83200", amount)
+	}
+
+	if _, _, err := parseMinSalary("lots"); err == nil {
+		t.Fatalf("parseMinSalary() error = nil, want error")
+	}
+}
+
+func TestParseRadius(t *testing.T) {
+	origin, radiusKm, err := parseRadius("50km", "München", "de")
+	if err != nil {
+		t.Fatalf("parseRadius() error = %v", err)
+	}
+	if origin.City != "Munich" || radiusKm != 50 {
+		t.Fatalf("parseRadius() = %+v %v, want Munich 50", origin, radiusKm)
+	}
+
+	if _, _, err := parseRadius("50km", "Atlantis", ""); err == nil {
+		t.Fatalf("parseRadius() unknown location error = nil, want error")
+	}
+	if _, _, err := parseRadius("far", "Munich", ""); err == nil {
+		t.Fatalf("parseRadius() invalid distance error = nil, want error")
+	}
+	if _, radiusKm, err := parseRadius("", "", ""); err != nil || radiusKm != 0 {
+		t.Fatalf("parseRadius(\"\") = %v, %v, want 0, nil", radiusKm, err)
+	}
+}
+
+func TestParseArrangementList(t *testing.T) {
+	arrangements, err := parseArrangementList("hybrid, On-Site", true)
+	if err != nil {
+		t.Fatalf("parseArrangementList() error = %v", err)
+	}
+	if strings.Join(arrangements, ",") != "hybrid,onsite,remote" {
+		t.Fatalf("parseArrangementList() = %v, want [hybrid onsite remote]", arrangements)
+	}
+
+	if _, err := parseArrangementList("anywhere", false); err == nil {
+		t.Fatalf("parseArrangementList() error = nil, want error")
+	}
+}
+
+func TestParseSeniorityList(t *testing.T) {
+	levels, err := parseSeniorityList("--seniority", " Senior, staff,,LEAD ")
+	if err != nil {
+		t.Fatalf("parseSeniorityList() error = %v", err)
+	}
+	if strings.Join(levels, ",") != "senior,staff,lead" {
+		t.Fatalf("parseSeniorityList() = %v, want [senior staff lead]", levels)
+	}
+
+	if _, err := parseSeniorityList("--seniority", "expert"); err == nil {
+		t.Fatalf("parseSeniorityList() error = nil, want error")
+	}
+}
+
+func TestFormatFilterSummary(t *testing.T) {
+	stats := filter.Stats{Dropped: map[filter.Reason]int{
+		filter.ReasonJobType:     1,
+		filter.ReasonHours:       3,
+		filter.ReasonArrangement: 0,
+	}}
+
+	got := formatFilterSummary(stats)
+	want := "filtered: dropped=4 by_reason=hours:3, job_type:1"
+	if got != want {
+		t.Fatalf("formatFilterSummary() = %q, want %q", got, want)
+	}
+}
+
 func TestMultiQuerySeenWorkflowAndLimitPerQuery(t *testing.T) {
 	dir := t.TempDir()
 	seenPath := filepath.Join(dir, "jobs_seen.json")
@@ -652,6 +938,7 @@ func TestMultiQuerySeenWorkflowAndLimitPerQuery(t *testing.T) {
 
 	merged := mergeUniqueJobs(nil, limitedQ1)
 	merged = mergeUniqueJobs(merged, limitedQ2)
+	merged, _ = dedupe.Merge(merged)
 	if len(merged) != 3 {
 		t.Fatalf("len(merged) = %d, want 3", len(merged))
 	}
@@ -663,12 +950,12 @@ func TestMultiQuerySeenWorkflowAndLimitPerQuery(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ReadJobsAllowMissing() error = %v", err)
 	}
-	unseenJobs, _ := seen.Diff(merged, seenJobs)
+	unseenJobs, _ := seen.Diff(merged, seenJobs, seen.DefaultKeyStrategy)
 	if len(unseenJobs) != 2 {
 		t.Fatalf("len(unseenJobs) = %d, want 2", len(unseenJobs))
 	}
 
-	if err := updateSeenHistory(seenPath, unseenJobs); err != nil {
+	if err := updateSeenHistory(seenPath, unseenJobs, "", 0); err != nil {
 		t.Fatalf("updateSeenHistory() error = %v", err)
 	}
 	updatedSeen, err := seen.ReadJobs(seenPath)
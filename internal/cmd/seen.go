@@ -1,28 +1,87 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/jimezsa/jobcli/internal/export"
+	"github.com/jimezsa/jobcli/internal/fsutil"
 	"github.com/jimezsa/jobcli/internal/seen"
 )
 
 type SeenCmd struct {
-	Diff   SeenDiffCmd   `cmd:"" help:"Write unseen jobs (A-B) to JSON."`
-	Update SeenUpdateCmd `cmd:"" help:"Merge new jobs into seen history JSON."`
+	Diff    SeenDiffCmd    `cmd:"" help:"Write unseen jobs (A-B) to JSON."`
+	Update  SeenUpdateCmd  `cmd:"" help:"Merge new jobs into seen history JSON."`
+	Import  SeenImportCmd  `cmd:"" help:"Import a seen history JSON file into a SQLite store."`
+	Export  SeenExportCmd  `cmd:"" help:"Export a SQLite seen store to a JSON file."`
+	Stale   SeenStaleCmd   `cmd:"" help:"List seen jobs that have not been observed for a number of days."`
+	Prune   SeenPruneCmd   `cmd:"" help:"Drop seen jobs that have not been observed for a given age."`
+	Compact SeenCompactCmd `cmd:"" help:"Drop duplicate keys and job details not needed for matching."`
+	Stats   SeenStatsCmd   `cmd:"" help:"Summarize seen history by site, company and month."`
 }
 
 type SeenDiffCmd struct {
-	New   string `name:"new" required:"" help:"Path to new jobs JSON file (A)."`
-	Seen  string `name:"seen" required:"" help:"Path to seen jobs JSON file (B). Missing file is treated as empty."`
+	New   string `name:"new" required:"" help:"Path to new jobs (A): a JSON or JSON Lines file, optionally gzip-compressed, or - for stdin."`
+	Seen  string `name:"seen" required:"" help:"Path to seen jobs history (B): a JSON file or a SQLite store (.db). Missing file is treated as empty."`
 	Out   string `name:"out" required:"" help:"Output path for unseen jobs JSON file (C)."`
 	Stats bool   `name:"stats" help:"Print comparison stats."`
+	Key   string `name:"seen-key" help:"Key strategy: title-company, title-company-location, url, id, fuzzy (default: the strategy recorded in --seen, else title-company)." enum:",title-company,title-company-location,url,id,fuzzy" default:""`
 }
 
 type SeenUpdateCmd struct {
-	Seen  string `name:"seen" required:"" help:"Path to seen jobs JSON file (B). Missing file is treated as empty."`
-	Input string `name:"input" required:"" help:"Path to input jobs JSON file to merge into seen history."`
-	Out   string `name:"out" required:"" help:"Output path for updated seen jobs JSON."`
-	Stats bool   `name:"stats" help:"Print merge stats."`
+	Seen    string `name:"seen" required:"" help:"Path to seen jobs history (B): a JSON file or a SQLite store (.db). Missing file is treated as empty."`
+	Input   string `name:"input" required:"" help:"Path to input jobs to merge into seen history: a JSON or JSON Lines file, optionally gzip-compressed, or - for stdin."`
+	Out     string `name:"out" required:"" help:"Output path for updated seen jobs JSON."`
+	Stats   bool   `name:"stats" help:"Print merge stats."`
+	Key     string `name:"seen-key" help:"Key strategy: title-company, title-company-location, url, id, fuzzy (default: the strategy recorded in --seen, else title-company). Recorded in --out." enum:",title-company,title-company-location,url,id,fuzzy" default:""`
+	Backups int    `name:"backups" help:"Keep N rolling backups of --out (<out>.bak.1 is the newest)."`
+}
+
+type SeenImportCmd struct {
+	Input   string `name:"input" required:"" help:"Path to seen jobs to import: a JSON or JSON Lines file, optionally gzip-compressed, or - for stdin."`
+	Seen    string `name:"seen" required:"" help:"Path to SQLite store (.db, .sqlite, .sqlite3). Created when missing."`
+	Stats   bool   `name:"stats" help:"Print merge stats."`
+	Key     string `name:"seen-key" help:"Key strategy: title-company, title-company-location, url, id, fuzzy (default: the strategy recorded in --seen, else in --input, else title-company)." enum:",title-company,title-company-location,url,id,fuzzy" default:""`
+	Backups int    `name:"backups" help:"Keep N rolling backups of --seen (<seen>.bak.1 is the newest)."`
+}
+
+type SeenExportCmd struct {
+	Seen  string `name:"seen" required:"" help:"Path to SQLite store (.db, .sqlite, .sqlite3)."`
+	Out   string `name:"out" required:"" help:"Output path for seen jobs JSON file."`
+	Stats bool   `name:"stats" help:"Print export stats."`
+}
+
+type SeenStaleCmd struct {
+	Seen  string `name:"seen" required:"" help:"Path to seen jobs history: a JSON file or a SQLite store (.db)."`
+	Days  int    `name:"days" default:"30" help:"List jobs whose last_seen is more than this many days ago."`
+	Out   string `name:"out" help:"Write stale jobs JSON to a file instead of printing them."`
+	Stats bool   `name:"stats" help:"Print stale stats."`
+}
+
+type SeenPruneCmd struct {
+	Seen      string `name:"seen" required:"" help:"Path to seen jobs history: a JSON file or a SQLite store (.db)."`
+	OlderThan string `name:"older-than" required:"" help:"Drop jobs whose last_seen is older than this age, e.g. 90d, 12w or 36h."`
+	Out       string `name:"out" help:"Write the pruned history here instead of rewriting --seen."`
+	Backups   int    `name:"backups" help:"Keep N rolling backups of the rewritten file (<file>.bak.1 is the newest)."`
+	Stats     bool   `name:"stats" help:"Print prune stats."`
+}
+
+type SeenCompactCmd struct {
+	Seen    string `name:"seen" required:"" help:"Path to seen jobs history: a JSON file or a SQLite store (.db)."`
+	Out     string `name:"out" help:"Write the compacted history here instead of rewriting --seen."`
+	Backups int    `name:"backups" help:"Keep N rolling backups of the rewritten file (<file>.bak.1 is the newest)."`
+	Stats   bool   `name:"stats" help:"Print compaction stats."`
+}
+
+type SeenStatsCmd struct {
+	Seen string `name:"seen" required:"" help:"Path to seen jobs history: a JSON file or a SQLite store (.db)."`
+	Key  string `name:"seen-key" help:"Key strategy used to count invalid and duplicate keys (default: the strategy recorded in --seen, else title-company)." enum:",title-company,title-company-location,url,id,fuzzy" default:""`
+	Top  int    `name:"top" default:"10" help:"Number of companies to list (0 lists all)."`
 }
 
 func (c *SeenDiffCmd) Run(ctx *Context) error {
@@ -30,12 +89,16 @@ func (c *SeenDiffCmd) Run(ctx *Context) error {
 	if err != nil {
 		return fmt.Errorf("read --new: %w", err)
 	}
-	seenJobs, err := seen.ReadJobsAllowMissing(c.Seen)
+	history, err := seen.ReadHistoryAllowMissing(c.Seen)
 	if err != nil {
 		return fmt.Errorf("read --seen: %w", err)
 	}
+	key, err := seen.ParseKeyStrategy(c.Key)
+	if err != nil {
+		return err
+	}
 
-	unseenJobs, stats := seen.Diff(newJobs, seenJobs)
+	unseenJobs, stats := seen.Diff(newJobs, history.Jobs, seen.ResolveKeyStrategy(key, history.KeyStrategy))
 	if err := seen.WriteJobs(c.Out, unseenJobs); err != nil {
 		return fmt.Errorf("write --out: %w", err)
 	}
@@ -56,18 +119,41 @@ func (c *SeenDiffCmd) Run(ctx *Context) error {
 }
 
 func (c *SeenUpdateCmd) Run(ctx *Context) error {
-	seenJobs, err := seen.ReadJobsAllowMissing(c.Seen)
+	if c.Backups < 0 {
+		return fmt.Errorf("--backups must be >= 0")
+	}
+	lock, err := lockSeen(c.Out)
 	if err != nil {
-		return fmt.Errorf("read --seen: %w", err)
+		return err
 	}
+	defer lock.Unlock()
+
 	inputJobs, err := seen.ReadJobs(c.Input)
 	if err != nil {
 		return fmt.Errorf("read --input: %w", err)
 	}
+	key, err := seen.ParseKeyStrategy(c.Key)
+	if err != nil {
+		return err
+	}
 
-	mergedJobs, stats := seen.Merge(seenJobs, inputJobs)
-	if err := seen.WriteJobs(c.Out, mergedJobs); err != nil {
-		return fmt.Errorf("write --out: %w", err)
+	var stats seen.MergeStats
+	if pathsEqual(c.Seen, c.Out) {
+		stats, err = updateSeenFile(c.Out, seen.History{Jobs: inputJobs}, key, c.Backups)
+		if err != nil {
+			return fmt.Errorf("update --out: %w", err)
+		}
+	} else {
+		history, err := seen.ReadHistoryAllowMissing(c.Seen)
+		if err != nil {
+			return fmt.Errorf("read --seen: %w", err)
+		}
+		strategy := seen.ResolveKeyStrategy(key, history.KeyStrategy)
+		mergedJobs, mergeStats := seen.Merge(history.Jobs, inputJobs, strategy, time.Now())
+		stats = mergeStats
+		if err := writeSeenHistory(c.Out, seen.History{KeyStrategy: strategy, Jobs: mergedJobs}, c.Backups); err != nil {
+			return fmt.Errorf("write --out: %w", err)
+		}
 	}
 
 	if c.Stats {
@@ -85,3 +171,352 @@ func (c *SeenUpdateCmd) Run(ctx *Context) error {
 
 	return nil
 }
+
+func (c *SeenImportCmd) Run(ctx *Context) error {
+	if !seen.IsSQLitePath(c.Seen) {
+		return fmt.Errorf("--seen must be a SQLite store (.db, .sqlite or .sqlite3)")
+	}
+	if c.Backups < 0 {
+		return fmt.Errorf("--backups must be >= 0")
+	}
+	input, err := seen.ReadHistory(c.Input)
+	if err != nil {
+		return fmt.Errorf("read --input: %w", err)
+	}
+	lock, err := lockSeen(c.Seen)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	key, err := seen.ParseKeyStrategy(c.Key)
+	if err != nil {
+		return err
+	}
+
+	stats, err := updateSeenFile(c.Seen, input, key, c.Backups)
+	if err != nil {
+		return fmt.Errorf("update --seen: %w", err)
+	}
+
+	if c.Stats {
+		_, err := fmt.Fprintf(
+			ctx.Out,
+			"total_seen=%d total_input=%d invalid_skipped=%d added=%d total_out=%d\n",
+			stats.TotalSeen,
+			stats.TotalInput,
+			stats.InvalidSkipped(),
+			stats.Added,
+			stats.TotalOut,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *SeenExportCmd) Run(ctx *Context) error {
+	if !seen.IsSQLitePath(c.Seen) {
+		return fmt.Errorf("--seen must be a SQLite store (.db, .sqlite or .sqlite3)")
+	}
+	if seen.IsSQLitePath(c.Out) {
+		return fmt.Errorf("--out must be a JSON file")
+	}
+	history, err := seen.ReadHistory(c.Seen)
+	if err != nil {
+		return fmt.Errorf("read --seen: %w", err)
+	}
+	if err := seen.WriteHistory(c.Out, history); err != nil {
+		return fmt.Errorf("write --out: %w", err)
+	}
+
+	if c.Stats {
+		_, err := fmt.Fprintf(ctx.Out, "total_out=%d key_strategy=%s\n", len(history.Jobs), seen.ResolveKeyStrategy(history.KeyStrategy, ""))
+		return err
+	}
+
+	return nil
+}
+
+func (c *SeenStaleCmd) Run(ctx *Context) error {
+	if c.Days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	history, err := seen.ReadHistory(c.Seen)
+	if err != nil {
+		return fmt.Errorf("read --seen: %w", err)
+	}
+
+	cutoff := time.Now().Add(-time.Duration(c.Days) * 24 * time.Hour)
+	staleJobs, stats := seen.Stale(history.Jobs, cutoff)
+	if c.Out != "" {
+		if err := seen.WriteJobs(c.Out, staleJobs); err != nil {
+			return fmt.Errorf("write --out: %w", err)
+		}
+	} else {
+		format, err := resolveFormat(ctx, SearchOptions{}, "")
+		if err != nil {
+			return err
+		}
+		colorEnabled := ctx.UI != nil && ctx.UI.ColorEnabled
+		if err := export.WriteJobs(ctx.Out, staleJobs, format, export.WriteOptions{
+			ColorEnabled: colorEnabled,
+			Hyperlinks:   colorEnabled && isTTY(ctx.Out),
+			LinkStyle:    export.LinkStyleFull,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if c.Stats {
+		// Keep stats off stdout when the jobs are printed there.
+		w := ctx.Out
+		if c.Out == "" {
+			w = ctx.Err
+		}
+		_, err := fmt.Fprintf(
+			w,
+			"total_seen=%d unknown_skipped=%d stale=%d\n",
+			stats.TotalSeen,
+			stats.Unknown,
+			stats.Stale,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *SeenPruneCmd) Run(ctx *Context) error {
+	age, err := parseAge(c.OlderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+	if c.Backups < 0 {
+		return fmt.Errorf("--backups must be >= 0")
+	}
+	out := c.Out
+	if out == "" {
+		out = c.Seen
+	}
+	lock, err := lockSeen(out)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	history, err := seen.ReadHistory(c.Seen)
+	if err != nil {
+		return fmt.Errorf("read --seen: %w", err)
+	}
+	keptJobs, stats := seen.Prune(history.Jobs, time.Now().Add(-age))
+	if err := rewriteSeenHistory(out, seen.History{KeyStrategy: history.KeyStrategy, Jobs: keptJobs}, c.Backups); err != nil {
+		return err
+	}
+
+	if c.Stats {
+		_, err := fmt.Fprintf(
+			ctx.Out,
+			"total_seen=%d unknown_kept=%d tracked_kept=%d pruned=%d total_out=%d\n",
+			stats.TotalSeen,
+			stats.Unknown,
+			stats.Tracked,
+			stats.Pruned,
+			stats.TotalOut,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *SeenCompactCmd) Run(ctx *Context) error {
+	if c.Backups < 0 {
+		return fmt.Errorf("--backups must be >= 0")
+	}
+	out := c.Out
+	if out == "" {
+		out = c.Seen
+	}
+	lock, err := lockSeen(out)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	history, err := seen.ReadHistory(c.Seen)
+	if err != nil {
+		return fmt.Errorf("read --seen: %w", err)
+	}
+	compactedJobs, stats := seen.Compact(history.Jobs, seen.ResolveKeyStrategy(history.KeyStrategy, ""))
+	if err := rewriteSeenHistory(out, seen.History{KeyStrategy: history.KeyStrategy, Jobs: compactedJobs}, c.Backups); err != nil {
+		return err
+	}
+
+	if c.Stats {
+		_, err := fmt.Fprintf(
+			ctx.Out,
+			"total_seen=%d duplicates_dropped=%d compacted=%d total_out=%d\n",
+			stats.TotalSeen,
+			stats.Duplicates,
+			stats.Compacted,
+			stats.TotalOut,
+		)
+		return err
+	}
+
+	return nil
+}
+
+// seenStatsOutput is the --json form of `seen stats`.
+type seenStatsOutput struct {
+	TotalSeen     int          `json:"total_seen"`
+	Invalid       int          `json:"invalid"`
+	DuplicateKeys int          `json:"duplicate_keys"`
+	Tracked       int          `json:"tracked"`
+	KeyStrategy   string       `json:"key_strategy"`
+	BySite        []seen.Count `json:"by_site"`
+	ByCompany     []seen.Count `json:"by_company"`
+	ByMonth       []seen.Count `json:"by_month"`
+}
+
+func (c *SeenStatsCmd) Run(ctx *Context) error {
+	if c.Top < 0 {
+		return fmt.Errorf("--top must be >= 0")
+	}
+	history, err := seen.ReadHistory(c.Seen)
+	if err != nil {
+		return fmt.Errorf("read --seen: %w", err)
+	}
+	key, err := seen.ParseKeyStrategy(c.Key)
+	if err != nil {
+		return err
+	}
+
+	strategy := seen.ResolveKeyStrategy(key, history.KeyStrategy)
+	stats := seen.Summarize(history.Jobs, strategy)
+	companies := seen.SortedCounts(stats.ByCompany)
+	if c.Top > 0 && len(companies) > c.Top {
+		companies = companies[:c.Top]
+	}
+	// Months read best in calendar order.
+	months := seen.SortedCounts(stats.ByMonth)
+	sort.Slice(months, func(i, j int) bool { return months[i].Name < months[j].Name })
+
+	output := seenStatsOutput{
+		TotalSeen:     stats.Total,
+		Invalid:       stats.Invalid,
+		DuplicateKeys: stats.DuplicateKeys,
+		Tracked:       stats.Tracked,
+		KeyStrategy:   string(strategy),
+		BySite:        seen.SortedCounts(stats.BySite),
+		ByCompany:     companies,
+		ByMonth:       months,
+	}
+	if ctx.JSONOutput {
+		encoder := json.NewEncoder(ctx.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if _, err := fmt.Fprintf(
+		ctx.Out,
+		"total_seen=%d invalid=%d duplicate_keys=%d tracked=%d key_strategy=%s\n",
+		output.TotalSeen,
+		output.Invalid,
+		output.DuplicateKeys,
+		output.Tracked,
+		output.KeyStrategy,
+	); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(ctx.Out, 0, 4, 2, ' ', 0)
+	for _, section := range []struct {
+		name   string
+		counts []seen.Count
+	}{
+		{"site", output.BySite},
+		{"company", output.ByCompany},
+		{"first_seen_month", output.ByMonth},
+	} {
+		fmt.Fprintf(tw, "\n%s\tjobs\n", section.name)
+		for _, count := range section.counts {
+			fmt.Fprintf(tw, "%s\t%d\n", count.Name, count.Count)
+		}
+	}
+	return tw.Flush()
+}
+
+// parseAge parses an age such as 90d, 12w or a Go duration like 36h.
+func parseAge(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	var age time.Duration
+	if unit != 0 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("%q: expected an age such as 90d, 12w or 36h", value)
+		}
+		age = time.Duration(n) * unit
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("%q: expected an age such as 90d, 12w or 36h", value)
+		}
+		age = parsed
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("%q: age must be positive", value)
+	}
+	return age, nil
+}
+
+// rewriteSeenHistory writes a pruned or compacted history and reclaims the
+// freed space of SQLite stores.
+func rewriteSeenHistory(path string, history seen.History, backups int) error {
+	if err := writeSeenHistory(path, history, backups); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := seen.Vacuum(path); err != nil {
+		return fmt.Errorf("vacuum %s: %w", path, err)
+	}
+	return nil
+}
+
+// seenLockTimeout bounds how long a run waits for another process updating
+// the same seen file.
+const seenLockTimeout = 30 * time.Second
+
+// lockSeen takes the advisory lock guarding read-modify-write of a seen file,
+// so overlapping runs against one shared file do not clobber each other.
+func lockSeen(path string) (*fsutil.Lock, error) {
+	lock, err := fsutil.LockPath(path, seenLockTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("lock %s: %w", path, err)
+	}
+	return lock, nil
+}
+
+// updateSeenFile rotates up to backups copies of the seen file at path, then
+// merges input into it with seen.UpdateHistory.
+func updateSeenFile(path string, input seen.History, key seen.KeyStrategy, backups int) (seen.MergeStats, error) {
+	if err := fsutil.Backup(path, backups); err != nil {
+		return seen.MergeStats{}, fmt.Errorf("back up %s: %w", path, err)
+	}
+	return seen.UpdateHistory(path, input, key, time.Now())
+}
+
+// writeSeenHistory rotates up to backups copies of the current file at path,
+// then writes history to it.
+func writeSeenHistory(path string, history seen.History, backups int) error {
+	if err := fsutil.Backup(path, backups); err != nil {
+		return fmt.Errorf("back up %s: %w", path, err)
+	}
+	return seen.WriteHistory(path, history)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "90d", want: 90 * 24 * time.Hour},
+		{value: " 2W ", want: 14 * 24 * time.Hour},
+		{value: "36h", want: 36 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := parseAge(tt.value)
+		if err != nil {
+			t.Fatalf("parseAge(%q) error = %v", tt.value, err)
+		}
+		if got != tt.want {
+			t.Fatalf("parseAge(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "90", "d", "0d", "-3d", "soon"} {
+		if _, err := parseAge(value); err == nil {
+			t.Fatalf("parseAge(%q) error = nil, want error", value)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/dedupe"
+	"github.com/jimezsa/jobcli/internal/export"
+	"github.com/jimezsa/jobcli/internal/filter"
+	"github.com/jimezsa/jobcli/internal/fsutil"
+	"github.com/jimezsa/jobcli/internal/models"
+	"github.com/jimezsa/jobcli/internal/scraper"
+	"github.com/jimezsa/jobcli/internal/seen"
+)
+
+// streamConfig is the validated search state runSearch hands to streamSearch.
+type streamConfig struct {
+	opts       SearchOptions
+	queries    []string
+	scrapers   []scraper.Scraper
+	params     models.SearchParams
+	criteria   filter.Criteria
+	seenKey    seen.KeyStrategy
+	outputPath string
+}
+
+// streamSearch is runSearch for --format ndjson. Each scraper's jobs go
+// through the same stages as a batch run and are written as soon as that
+// scraper finishes. Streamed jobs are not sorted, and a cross-site duplicate
+// of a job already written is not added to its sources, since written lines
+// are final. A cluster dropped by the filters is checked again when a later
+// posting joins it, so it is written once merging fills in what it lacked.
+func streamSearch(ctx *Context, cfg streamConfig) error {
+	opts := cfg.opts
+	seenPath := strings.TrimSpace(opts.Seen)
+
+	var history seen.History
+	var strategy seen.KeyStrategy
+	if seenPath != "" {
+		var err error
+		history, err = seen.ReadHistoryAllowMissing(opts.Seen)
+		if err != nil {
+			return fmt.Errorf("read --seen: %w", err)
+		}
+		strategy = seen.ResolveKeyStrategy(cfg.seenKey, history.KeyStrategy)
+	}
+
+	writer := ctx.Out
+	var file *fsutil.File
+	if cfg.outputPath != "" {
+		var err error
+		file, err = fsutil.Create(cfg.outputPath, 0o644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+	writeOpts := export.WriteOptions{DescriptionFormat: export.DescriptionFormat(opts.DescriptionFormat)}
+
+	criteria := cfg.criteria
+	if criteria.Now.IsZero() {
+		criteria.Now = time.Now()
+	}
+	var (
+		merger     dedupe.Merger
+		known      = map[string]bool{}       // site job keys found by earlier queries
+		written    = map[int]bool{}          // clusters already written
+		dropped    = map[int]filter.Reason{} // clusters the filters dropped so far
+		jobs       []models.Job
+		observed   []models.Job
+		unseenJobs []models.Job
+		failures   []scraperFailure
+		seenStats  seenRunStats
+	)
+
+	emit := func(batch []models.Job) error {
+		observed = append(observed, batch...)
+		output := batch
+		if seenPath != "" {
+			unseen, _ := seen.Diff(batch, history.Jobs, strategy)
+			unseenJobs = append(unseenJobs, unseen...)
+			seenStats.Reposted += seen.MarkReposts(batch, history.Jobs, strategy)
+			var ignored int
+			batch, ignored = seen.ApplyStatus(batch, history.Jobs, strategy)
+			seenStats.Ignored += ignored
+			output = batch
+			if opts.NewOnly {
+				output = unseen
+			}
+		}
+		jobs = append(jobs, batch...)
+		return export.WriteJobs(writer, output, export.FormatNDJSON, writeOpts)
+	}
+
+	for _, query := range cfg.queries {
+		params := cfg.params
+		params.Query = query
+		// Like limitJobs and mergeUniqueJobs in a batch run: the limit counts
+		// per query, and only jobs a site already returned for an earlier
+		// query are skipped.
+		var queryKeys []string
+		taken := 0
+		var writeErr error
+		streamScrapers(cfg.scrapers, params, func(res scraperResult) {
+			if writeErr != nil {
+				return
+			}
+			if res.err != nil {
+				failures = append(failures, newScraperFailure(res))
+				return
+			}
+			batch := res.jobs
+			enrichJobs(batch, params)
+			tagQuery(batch, query)
+			if params.Limit > 0 {
+				if taken >= params.Limit {
+					return
+				}
+				batch = limitJobs(batch, params.Limit-taken)
+			}
+			taken += len(batch)
+
+			var touched []int
+			for _, job := range batch {
+				key, ok := siteJobKey(job)
+				if ok && known[key] {
+					continue
+				}
+				if ok {
+					queryKeys = append(queryKeys, key)
+				}
+				if index := merger.Add(job); !written[index] && !slices.Contains(touched, index) {
+					touched = append(touched, index)
+				}
+			}
+			var fresh []models.Job
+			for _, index := range touched {
+				job := merger.Job(index)
+				if reason, drop := filter.Check(job, criteria); drop {
+					dropped[index] = reason
+					continue
+				}
+				delete(dropped, index)
+				written[index] = true
+				fresh = append(fresh, job)
+			}
+			writeErr = emit(fresh)
+		})
+		if writeErr != nil {
+			return writeErr
+		}
+		for _, key := range queryKeys {
+			known[key] = true
+		}
+	}
+
+	filterStats := filter.Stats{Total: len(merger.Jobs()), Kept: len(written), Dropped: map[filter.Reason]int{}}
+	for _, reason := range dropped {
+		filterStats.Dropped[reason]++
+	}
+
+	sortScraperFailures(failures)
+	reportScraperFailures(ctx, failures)
+
+	if strings.TrimSpace(opts.NewOut) != "" {
+		if err := seen.WriteJobs(opts.NewOut, unseenJobs); err != nil {
+			return fmt.Errorf("write --new-out: %w", err)
+		}
+	}
+	if file != nil {
+		if err := file.Commit(); err != nil {
+			return err
+		}
+	}
+	if opts.SeenUpdate && seenPath != "" {
+		if err := updateSeenHistory(opts.Seen, observed, cfg.seenKey, opts.SeenBackups); err != nil {
+			return err
+		}
+	}
+
+	summaryJobs := jobs
+	if seenPath != "" {
+		summaryJobs = unseenJobs
+	}
+	printSearchSummary(ctx, summaryJobs, cfg.queries, merger.Stats(), filterStats, seenStats)
+	return nil
+}
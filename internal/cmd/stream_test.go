@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/filter"
+	"github.com/jimezsa/jobcli/internal/models"
+	"github.com/jimezsa/jobcli/internal/scraper"
+	"github.com/jimezsa/jobcli/internal/seen"
+)
+
+type fakeScraper struct {
+	name string
+	jobs []models.Job
+	// byQuery, when set, holds the jobs returned for each query instead of jobs.
+	byQuery map[string][]models.Job
+}
+
+func (f fakeScraper) Name() string { return f.name }
+
+func (f fakeScraper) Search(_ context.Context, params models.SearchParams) ([]models.Job, error) {
+	source := f.jobs
+	if f.byQuery != nil {
+		source = f.byQuery[params.Query]
+	}
+	jobs := make([]models.Job, len(source))
+	copy(jobs, source)
+	return jobs, nil
+}
+
+func TestStreamSearchWritesNDJSON(t *testing.T) {
+	seenPath := filepath.Join(t.TempDir(), "seen.json")
+	if err := seen.WriteJobs(seenPath, []models.Job{{Site: "indeed", Title: "Old Role", Company: "Gamma", URL: "https://example.com/old"}}); err != nil {
+		t.Fatalf("WriteJobs() error = %v", err)
+	}
+
+	scrapers := []scraper.Scraper{
+		fakeScraper{name: "linkedin", jobs: []models.Job{
+			{Site: "linkedin", Title: "Software Engineer (m/w/d)", Company: "ACME GmbH", URL: "https://example.com/1"},
+			{Site: "linkedin", Title: "Data Engineer", Company: "Beta", URL: "https://example.com/2"},
+		}},
+		fakeScraper{name: "indeed", jobs: []models.Job{
+			{Site: "indeed", Title: "Software Engineer m/f/d", Company: "Acme", URL: "https://example.com/3"},
+			{Site: "indeed", Title: "Old Role", Company: "Gamma", URL: "https://example.com/old"},
+		}},
+	}
+
+	var out, errOut bytes.Buffer
+	ctx := &Context{Out: &out, Err: &errOut}
+	err := streamSearch(ctx, streamConfig{
+		opts:     SearchOptions{Seen: seenPath, NewOnly: true, SeenUpdate: true},
+		queries:  []string{"engineer"},
+		scrapers: scrapers,
+	})
+	if err != nil {
+		t.Fatalf("streamSearch() error = %v", err)
+	}
+
+	var titles []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var job models.Job
+		if err := json.Unmarshal([]byte(line), &job); err != nil {
+			t.Fatalf("line %q is not a JSON job: %v", line, err)
+		}
+		titles = append(titles, job.Title)
+	}
+	if len(titles) != 2 {
+		t.Fatalf("streamed titles = %v, want the two unseen, deduplicated jobs", titles)
+	}
+	if !strings.Contains(errOut.String(), "summary: new_jobs=2") {
+		t.Fatalf("summary = %q, want new_jobs=2", errOut.String())
+	}
+
+	history, err := seen.ReadJobs(seenPath)
+	if err != nil {
+		t.Fatalf("ReadJobs() error = %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("seen history has %d jobs, want 3", len(history))
+	}
+}
+
+func TestStreamSearchMatchesBatchOutput(t *testing.T) {
+	// "Backend Engineer" has no salary on LinkedIn, so the strict salary
+	// filter drops it until the Indeed posting from the second query merges
+	// one in.
+	scrapers := []scraper.Scraper{
+		fakeScraper{name: "linkedin", byQuery: map[string][]models.Job{
+			"backend": {
+				{Site: "linkedin", Title: "Backend Engineer", Company: "Acme", URL: "https://example.com/li-1"},
+				{Site: "linkedin", Title: "Data Engineer", Company: "Beta", Salary: "60.000 - 70.000 EUR", URL: "https://example.com/li-2"},
+				{Site: "linkedin", Title: "Support Engineer", Company: "Gamma", URL: "https://example.com/li-3"},
+			},
+			"golang": {
+				{Site: "linkedin", Title: "Data Engineer", Company: "Beta", Salary: "60.000 - 70.000 EUR", URL: "https://example.com/li-2"},
+			},
+		}},
+		fakeScraper{name: "indeed", byQuery: map[string][]models.Job{
+			"golang": {
+				{Site: "indeed", Title: "Backend Engineer (m/w/d)", Company: "ACME GmbH", Salary: "65.000 EUR", URL: "https://example.com/in-1"},
+				{Site: "indeed", Title: "Junior Tester", Company: "Delta", Salary: "30.000 EUR", URL: "https://example.com/in-2"},
+			},
+		}},
+	}
+	queries := []string{"backend", "golang"}
+	criteria := filter.Criteria{MinSalary: 50000, MinSalaryCurrency: "EUR", Strict: true}
+
+	batch, _, _, err := searchJobs(scrapers, models.SearchParams{}, queries)
+	if err != nil {
+		t.Fatalf("searchJobs() error = %v", err)
+	}
+	batch, _ = filter.Apply(batch, criteria)
+
+	var out, errOut bytes.Buffer
+	ctx := &Context{Out: &out, Err: &errOut}
+	if err := streamSearch(ctx, streamConfig{queries: queries, scrapers: scrapers, criteria: criteria}); err != nil {
+		t.Fatalf("streamSearch() error = %v", err)
+	}
+	var streamed []models.Job
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var job models.Job
+		if err := json.Unmarshal([]byte(line), &job); err != nil {
+			t.Fatalf("line %q is not a JSON job: %v", line, err)
+		}
+		streamed = append(streamed, job)
+	}
+
+	keys := func(jobs []models.Job) []string {
+		var out []string
+		for _, job := range jobs {
+			key, _ := seen.KeyFuzzy.Key(job)
+			out = append(out, key)
+		}
+		slices.Sort(out)
+		return out
+	}
+	if got, want := keys(streamed), keys(batch); !slices.Equal(got, want) || len(want) != 2 {
+		t.Fatalf("streamed jobs = %q, batch jobs = %q; want the same two", got, want)
+	}
+	for _, job := range streamed {
+		if job.Company == "Acme" && len(job.Sources) != 2 {
+			t.Fatalf("promoted cluster sources = %v, want both postings", job.Sources)
+		}
+	}
+	if !strings.Contains(errOut.String(), "filtered: dropped=2") {
+		t.Fatalf("summary = %q, want dropped=2", errOut.String())
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/export"
+	"github.com/jimezsa/jobcli/internal/fsutil"
+	"github.com/jimezsa/jobcli/internal/models"
+	"github.com/jimezsa/jobcli/internal/scraper"
+	"github.com/jimezsa/jobcli/internal/seen"
+)
+
+type TrackCmd struct {
+	Set  TrackSetCmd  `cmd:"" help:"Set the application status of a job in the seen history."`
+	List TrackListCmd `cmd:"" help:"List jobs with an application status."`
+}
+
+type TrackSetCmd struct {
+	Job     string `arg:"" help:"Job ID, site:id, or job URL of a job in the seen history."`
+	Status  string `arg:"" help:"Status: applied, interviewing, offer, rejected, ignored, or none to clear." enum:"applied,interviewing,offer,rejected,ignored,none"`
+	Note    string `name:"note" help:"Note stored with the status."`
+	Seen    string `name:"seen" required:"" help:"Path to seen jobs history: a JSON file or a SQLite store (.db)."`
+	Backups int    `name:"backups" help:"Keep N rolling backups of --seen (<seen>.bak.1 is the newest)."`
+}
+
+type TrackListCmd struct {
+	Seen    string `name:"seen" required:"" help:"Path to seen jobs history: a JSON file or a SQLite store (.db)."`
+	Status  string `name:"status" help:"Comma-separated statuses to list (default: all statuses)."`
+	Site    string `name:"site" help:"Only list jobs from this site."`
+	Company string `name:"company" help:"Only list jobs whose company contains this text."`
+	Format  string `name:"format" help:"Output format: csv, json, md, ndjson, html, rss, atom, xlsx, ods (default: table on a TTY, CSV otherwise)." enum:",csv,json,md,ndjson,html,rss,atom,xlsx,ods" default:""`
+	Output  string `name:"output" short:"o" help:"Write the list to a file."`
+}
+
+func (c *TrackSetCmd) Run(ctx *Context) error {
+	if c.Backups < 0 {
+		return fmt.Errorf("--backups must be >= 0")
+	}
+	lock, err := lockSeen(c.Seen)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	history, err := seen.ReadHistory(c.Seen)
+	if err != nil {
+		return fmt.Errorf("read --seen: %w", err)
+	}
+
+	updated, err := seen.SetStatus(history.Jobs, c.Job, c.Status, c.Note, time.Now())
+	if err != nil {
+		return err
+	}
+	if updated == 0 && strings.Contains(c.Job, "://") {
+		// Pasted URLs may still carry tracking parameters; retry with the
+		// canonical URL and site job ID the scrapers record.
+		site, id, canonicalURL := scraper.Identify(c.Job)
+		updated, _ = seen.SetStatus(history.Jobs, canonicalURL, c.Status, c.Note, time.Now())
+		if updated == 0 && site != "" && id != "" {
+			updated, _ = seen.SetStatus(history.Jobs, site+":"+id, c.Status, c.Note, time.Now())
+		}
+	}
+	if updated == 0 {
+		return fmt.Errorf("no job in %s matches %q; add it with `jobcli seen update` or `search --seen-update` first", c.Seen, c.Job)
+	}
+
+	if err := writeSeenHistory(c.Seen, history, c.Backups); err != nil {
+		return fmt.Errorf("write --seen: %w", err)
+	}
+
+	_, err = fmt.Fprintf(ctx.Out, "updated=%d status=%s\n", updated, c.Status)
+	return err
+}
+
+func (c *TrackListCmd) Run(ctx *Context) error {
+	statuses := seen.Statuses
+	if strings.TrimSpace(c.Status) != "" {
+		statuses = nil
+		for _, value := range strings.Split(c.Status, ",") {
+			status, err := seen.ParseStatus(value)
+			if err != nil {
+				return fmt.Errorf("invalid --status: %w", err)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	history, err := seen.ReadHistory(c.Seen)
+	if err != nil {
+		return fmt.Errorf("read --seen: %w", err)
+	}
+
+	jobs := make([]models.Job, 0)
+	company := strings.ToLower(strings.TrimSpace(c.Company))
+	for _, job := range history.Jobs {
+		if !containsFold(statuses, job.Status) {
+			continue
+		}
+		if c.Site != "" && !strings.EqualFold(job.Site, strings.TrimSpace(c.Site)) {
+			continue
+		}
+		if company != "" && !strings.Contains(strings.ToLower(job.Company), company) {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	format, err := resolveFormat(ctx, SearchOptions{Format: c.Format}, c.Output)
+	if err != nil {
+		return err
+	}
+	writer := ctx.Out
+	var file *fsutil.File
+	if c.Output != "" {
+		file, err = fsutil.Create(c.Output, 0o644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	colorEnabled := ctx.UI != nil && ctx.UI.ColorEnabled
+	if err := export.WriteJobs(writer, jobs, format, export.WriteOptions{
+		ColorEnabled: colorEnabled,
+		Hyperlinks:   colorEnabled && isTTY(writer),
+		LinkStyle:    export.LinkStyleFull,
+		FeedTitle:    "jobcli: tracked jobs",
+	}); err != nil {
+		return err
+	}
+	if file != nil {
+		return file.Commit()
+	}
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package company
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/dedupe"
+)
+
+// List groups company names with the aliases they also post under, so that
+// naming any of "Google", "Google Germany GmbH" or "Alphabet" matches all
+// three.
+type List struct {
+	// groups maps each normalized name to every normalized name of its entry.
+	groups map[string][]string
+}
+
+// NewList builds a list from name -> aliases entries, as read from
+// companies.json. Entries sharing a name or alias are merged.
+func NewList(entries map[string][]string) List {
+	list := List{groups: map[string][]string{}}
+	for name, aliases := range entries {
+		var group []string
+		for _, value := range append([]string{name}, aliases...) {
+			key := Normalize(value)
+			if key == "" {
+				continue
+			}
+			group = appendUnique(group, key)
+			group = appendUnique(group, list.groups[key]...)
+		}
+		for _, key := range group {
+			list.groups[key] = group
+		}
+	}
+	return list
+}
+
+// Normalize is the comparison form of a company name: lower case, without
+// punctuation or trailing legal forms such as "GmbH" and "Inc.".
+func Normalize(name string) string {
+	return dedupe.NormalizeCompanyName(name)
+}
+
+// Set is a set of normalized company names.
+type Set map[string]bool
+
+// Resolve returns the set matching names and all of their aliases. Names
+// missing from the list match only themselves.
+func (l List) Resolve(names []string) Set {
+	set := Set{}
+	for _, name := range names {
+		key := Normalize(name)
+		if key == "" {
+			continue
+		}
+		set[key] = true
+		for _, alias := range l.groups[key] {
+			set[alias] = true
+		}
+	}
+	return set
+}
+
+// Contains reports whether company, normalized, is in the set.
+func (s Set) Contains(company string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	return s[Normalize(company)]
+}
+
+// ParseNames splits a comma-separated flag value into company names.
+func ParseNames(value string) []string {
+	var names []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			names = append(names, part)
+		}
+	}
+	return names
+}
+
+func appendUnique(values []string, more ...string) []string {
+	for _, value := range more {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
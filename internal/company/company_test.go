@@ -0,0 +1,59 @@
+package company
+
+import "testing"
+
+func TestList_Resolve(t *testing.T) {
+	list := NewList(map[string][]string{
+		"Google":   {"Google Germany GmbH", "Alphabet Inc."},
+		"Hays":     {"Hays AG"},
+		"Alphabet": {"Waymo"},
+	})
+
+	set := list.Resolve([]string{"google"})
+	for _, name := range []string{"Google", "GOOGLE GmbH", "Google Germany GmbH", "Alphabet", "Waymo LLC"} {
+		if !set.Contains(name) {
+			t.Errorf("Resolve(google).Contains(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"Hays", "Google Cloud", ""} {
+		if set.Contains(name) {
+			t.Errorf("Resolve(google).Contains(%q) = true, want false", name)
+		}
+	}
+
+	set = list.Resolve([]string{"Hays AG", "Acme"})
+	if !set.Contains("Hays") || !set.Contains("ACME GmbH") || set.Contains("Google") {
+		t.Fatalf("Resolve(Hays AG, Acme) = %v", set)
+	}
+
+	if set := list.Resolve(nil); len(set) != 0 || set.Contains("Google") {
+		t.Fatalf("Resolve(nil) = %v, want empty", set)
+	}
+}
+
+func TestList_ResolveKeepsLeadingLegalWords(t *testing.T) {
+	list := NewList(map[string][]string{
+		"AS Roma":                {"Associazione Sportiva Roma"},
+		"The Coca Company Group": nil,
+	})
+
+	set := list.Resolve([]string{"AS Roma"})
+	if !set.Contains("AS Roma S.p.A.") || !set.Contains("Associazione Sportiva Roma") {
+		t.Fatalf("Resolve(AS Roma) = %v, want AS Roma and its alias", set)
+	}
+	if set.Contains("Roma") {
+		t.Fatalf("Resolve(AS Roma).Contains(Roma) = true, want false")
+	}
+
+	set = list.Resolve([]string{"The Coca Company Group"})
+	if !set.Contains("The Coca Company Group") || set.Contains("The Coca Group") {
+		t.Fatalf("Resolve(The Coca Company Group) = %v", set)
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	got := ParseNames(" Google, ,Hays AG ,")
+	if len(got) != 2 || got[0] != "Google" || got[1] != "Hays AG" {
+		t.Fatalf("ParseNames() = %q", got)
+	}
+}
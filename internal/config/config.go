@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -16,8 +17,18 @@ const (
 	ConfigFileName  = "config.json"
 	ProxiesFileName = "proxies.txt"
 	CookiesFileName = "cookies.json"
+	// CompaniesFileName lists company names with their aliases.
+	CompaniesFileName = "companies.json"
 )
 
+// companiesTemplate is the companies.json written by Init.
+const companiesTemplate = `// Company names and the aliases they also post under, used by
+// --exclude-companies, --only-companies and --boost-companies.
+// Matching ignores case, punctuation and legal forms such as "GmbH".
+// Example: {"Google": ["Google Germany GmbH", "Alphabet"]}
+{}
+`
+
 // Config contains default search settings.
 type Config struct {
 	DefaultLocation string `json:"default_location"`
@@ -57,6 +68,14 @@ func ProxiesPath() (string, error) {
 	return filepath.Join(dir, ProxiesFileName), nil
 }
 
+func CompaniesPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, CompaniesFileName), nil
+}
+
 func Load() (Config, error) {
 	cfg := DefaultConfig()
 	path, err := ConfigPath()
@@ -83,7 +102,8 @@ func Load() (Config, error) {
 	return cfg, nil
 }
 
-// Init writes default config.json and proxies.txt if they don't already exist.
+// Init writes default config.json, proxies.txt and companies.json if they
+// don't already exist.
 func Init() ([]string, error) {
 	var created []string
 
@@ -111,6 +131,14 @@ func Init() ([]string, error) {
 		created = append(created, proxiesPath)
 	}
 
+	companiesPath := filepath.Join(dir, CompaniesFileName)
+	if _, err := os.Stat(companiesPath); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(companiesPath, []byte(companiesTemplate), 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, companiesPath)
+	}
+
 	return created, nil
 }
 
@@ -155,6 +183,32 @@ func LoadProxies(flagValue string) ([]string, error) {
 	return proxies, nil
 }
 
+// LoadCompanies reads companies.json, a JSON object mapping each company
+// name to its aliases. A missing file is an empty list.
+func LoadCompanies() (map[string][]string, error) {
+	path, err := CompaniesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	companies := map[string][]string{}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return companies, nil
+	}
+	if err := json5.Unmarshal(data, &companies); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return companies, nil
+}
+
 func envString(key, fallback string) string {
 	if val := strings.TrimSpace(os.Getenv(key)); val != "" {
 		return val
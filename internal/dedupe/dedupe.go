@@ -0,0 +1,346 @@
+package dedupe
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/jimezsa/jobcli/internal/location"
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+const (
+	// titleThreshold and companyThreshold are the minimum token similarities
+	// for two postings to be treated as the same job.
+	titleThreshold   = 0.8
+	companyThreshold = 0.8
+	// maxDistanceKm is how far apart two normalized locations may be.
+	maxDistanceKm = 25
+)
+
+// Stats counts the duplicates folded into other jobs.
+type Stats struct {
+	Total    int
+	Merged   int
+	Clusters int
+}
+
+// genderSuffixPattern matches German gender-inclusive endings such as
+// "Entwickler*in", "Entwickler:innen" or "Berater/in".
+var genderSuffixPattern = regexp.MustCompile(`(?i)[*:_/](in|innen)\b`)
+
+// genderLetters are the letters used in markers like (m/w/d), m/f/d, (w/m/x) or (h/f).
+var genderLetters = map[string]bool{"m": true, "w": true, "d": true, "f": true, "x": true, "h": true}
+
+// genderPhrases are whole-token markers dropped from titles.
+var genderPhrases = [][]string{
+	{"all", "genders"},
+	{"alle", "geschlechter"},
+	{"gn"},
+}
+
+// legalSuffixes are company-form tokens dropped before comparing companies.
+var legalSuffixes = map[string]bool{
+	"gmbh": true, "mbh": true, "ag": true, "se": true, "kg": true, "kgaa": true, "ug": true,
+	"ohg": true, "gbr": true, "ev": true, "co": true, "inc": true, "incorporated": true,
+	"llc": true, "llp": true, "ltd": true, "limited": true, "plc": true, "corp": true,
+	"corporation": true, "company": true, "sa": true, "sas": true, "sarl": true, "sl": true,
+	"bv": true, "nv": true, "ab": true, "oy": true, "as": true, "srl": true, "spa": true,
+	"pty": true, "pte": true, "haftungsbeschrankt": true, "haftungsbeschränkt": true,
+}
+
+// Merge clusters postings of the same job found on different sites ("Software
+// Engineer (m/w/d)" at "ACME GmbH" on Stepstone and "Software Engineer m/f/d" at
+// "ACME" on LinkedIn) into the first posting of each cluster. Missing fields are
+// filled from the other postings and every posting is listed in Job.Sources.
+// A cluster never takes two postings from the same site.
+func Merge(jobs []models.Job) ([]models.Job, Stats) {
+	var merger Merger
+	for _, job := range jobs {
+		merger.Add(job)
+	}
+	return merger.Jobs(), merger.Stats()
+}
+
+// Merger is Merge for postings that arrive over time. The zero value is ready
+// to use; after any sequence of Add calls, Jobs and Stats equal what Merge
+// returns for the same postings.
+type Merger struct {
+	jobs     []models.Job
+	clusters []*cluster
+	stats    Stats
+}
+
+type cluster struct {
+	title   []string
+	company []string
+	sites   map[string]bool
+	size    int
+}
+
+// Add merges job into its cluster, or starts a new one, and returns the index
+// of that cluster in Jobs.
+func (m *Merger) Add(job models.Job) int {
+	m.stats.Total++
+	title := titleTokens(job.Title)
+	company := companyTokens(job.Company)
+
+	match := -1
+	if len(title) > 0 && len(company) > 0 {
+		for index, candidate := range m.clusters {
+			if candidate.sites[siteKey(job)] {
+				continue
+			}
+			if similarity(candidate.title, title) < titleThreshold ||
+				similarity(candidate.company, company) < companyThreshold ||
+				!sameLocation(m.jobs[index], job) {
+				continue
+			}
+			match = index
+			break
+		}
+	}
+
+	if match < 0 {
+		m.clusters = append(m.clusters, &cluster{
+			title:   title,
+			company: company,
+			sites:   map[string]bool{siteKey(job): true},
+			size:    1,
+		})
+		m.jobs = append(m.jobs, job)
+		return len(m.jobs) - 1
+	}
+
+	primary := &m.jobs[match]
+	found := m.clusters[match]
+	if found.size == 1 {
+		addSource(primary, *primary)
+		m.stats.Clusters++
+	}
+	fillMissing(primary, job)
+	addSource(primary, job)
+	AddQueries(primary, job)
+	found.sites[siteKey(job)] = true
+	found.size++
+	m.stats.Merged++
+	return match
+}
+
+// Job returns the merged job of the cluster at index.
+func (m *Merger) Job(index int) models.Job {
+	return m.jobs[index]
+}
+
+// Jobs returns one merged job per cluster, in order of first posting.
+func (m *Merger) Jobs() []models.Job {
+	return m.jobs
+}
+
+// Stats returns the counts for every posting added so far.
+func (m *Merger) Stats() Stats {
+	return m.stats
+}
+
+// NormalizeTitle lowercases a title and strips punctuation and gender markers.
+func NormalizeTitle(title string) string {
+	return strings.Join(titleTokens(title), " ")
+}
+
+// NormalizeCompany lowercases a company name and strips punctuation and legal suffixes.
+func NormalizeCompany(company string) string {
+	return strings.Join(companyTokens(company), " ")
+}
+
+// NormalizeCompanyName is NormalizeCompany for exact name lookups: legal
+// suffixes are only stripped from the end, so "AS Roma" and "The Coca Company
+// Group" keep their words.
+func NormalizeCompanyName(company string) string {
+	tokens := tokenize(strings.ReplaceAll(company, ".", ""))
+	end := len(tokens)
+	for end > 0 && legalSuffixes[tokens[end-1]] {
+		end--
+	}
+	if end == 0 {
+		return strings.Join(tokens, " ")
+	}
+	return strings.Join(tokens[:end], " ")
+}
+
+func titleTokens(title string) []string {
+	tokens := tokenize(genderSuffixPattern.ReplaceAllString(title, ""))
+	out := tokens[:0]
+	for i := 0; i < len(tokens); i++ {
+		if n := genderMarkerLength(tokens[i:]); n > 0 {
+			i += n - 1
+			continue
+		}
+		out = append(out, tokens[i])
+	}
+	return out
+}
+
+// genderMarkerLength returns how many leading tokens form a gender marker.
+func genderMarkerLength(tokens []string) int {
+	run := 0
+	for run < len(tokens) && genderLetters[tokens[run]] {
+		run++
+	}
+	if run >= 2 {
+		return run
+	}
+	for _, phrase := range genderPhrases {
+		if len(tokens) < len(phrase) {
+			continue
+		}
+		matched := true
+		for i, word := range phrase {
+			if tokens[i] != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return len(phrase)
+		}
+	}
+	return 0
+}
+
+func companyTokens(company string) []string {
+	// "S.A.", "e.V." and "B.V." collapse to single tokens.
+	company = strings.ReplaceAll(company, ".", "")
+	tokens := tokenize(company)
+	out := tokens[:0]
+	for _, token := range tokens {
+		if legalSuffixes[token] {
+			continue
+		}
+		out = append(out, token)
+	}
+	return out
+}
+
+func tokenize(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+}
+
+// similarity is the Jaccard index of the two token sets.
+func similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := make(map[string]bool, len(a))
+	for _, token := range a {
+		set[token] = true
+	}
+	union := len(set)
+	intersection := 0
+	counted := map[string]bool{}
+	for _, token := range b {
+		if counted[token] {
+			continue
+		}
+		counted[token] = true
+		if set[token] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+	return float64(intersection) / float64(union)
+}
+
+// sameLocation rejects pairs whose normalized locations are clearly different;
+// unknown locations do not block a match.
+func sameLocation(a, b models.Job) bool {
+	placeA, placeB := location.FromJob(a), location.FromJob(b)
+	if placeA.HasCoordinates() && placeB.HasCoordinates() {
+		return location.DistanceKm(placeA, placeB) <= maxDistanceKm
+	}
+	if placeA.Country != "" && placeB.Country != "" {
+		return placeA.Country == placeB.Country
+	}
+	return true
+}
+
+func siteKey(job models.Job) string {
+	return strings.ToLower(strings.TrimSpace(job.Site))
+}
+
+func addSource(job *models.Job, from models.Job) {
+	if strings.TrimSpace(from.URL) == "" {
+		return
+	}
+	for _, source := range job.Sources {
+		if source.URL == from.URL {
+			return
+		}
+	}
+	job.Sources = append(job.Sources, models.JobSource{Site: from.Site, URL: from.URL})
+}
+
+// AddQueries records the queries and query hits of from on job, skipping
+// ones it already lists. It always allocates new slices, so copies of job
+// are not affected.
+func AddQueries(job *models.Job, from models.Job) {
+	queries := append([]string(nil), job.Queries...)
+	for _, query := range from.Queries {
+		if !slices.Contains(queries, query) {
+			queries = append(queries, query)
+		}
+	}
+	job.Queries = queries
+	hits := append([]models.QueryHit(nil), job.QueryHits...)
+	for _, hit := range from.QueryHits {
+		if !slices.Contains(hits, hit) {
+			hits = append(hits, hit)
+		}
+	}
+	job.QueryHits = hits
+}
+
+// fillMissing copies fields the primary posting lacks from a duplicate.
+func fillMissing(job *models.Job, from models.Job) {
+	fillString(&job.Location, from.Location)
+	if job.City == "" && job.CountryCode == "" {
+		job.City, job.Region, job.CountryCode = from.City, from.Region, from.CountryCode
+		job.Latitude, job.Longitude = from.Latitude, from.Longitude
+	}
+	if job.WorkArrangement == "" || job.WorkArrangement == models.WorkArrangementUnknown {
+		job.WorkArrangement = from.WorkArrangement
+		job.Remote = from.Remote
+	}
+	fillString(&job.JobType, from.JobType)
+	fillString(&job.EmploymentType, from.EmploymentType)
+	fillString(&job.Seniority, from.Seniority)
+	fillString(&job.Salary, from.Salary)
+	if job.SalaryMin == 0 && job.SalaryMax == 0 {
+		job.SalaryMin, job.SalaryMax = from.SalaryMin, from.SalaryMax
+		job.SalaryCurrency, job.SalaryPeriod = from.SalaryCurrency, from.SalaryPeriod
+		job.SalaryAnnual = from.SalaryAnnual
+	}
+	if job.Description == "" {
+		job.Description = from.Description
+		job.DescriptionMarkdown = from.DescriptionMarkdown
+		job.DescriptionHTML = from.DescriptionHTML
+	}
+	fillString(&job.Snippet, from.Snippet)
+	job.Agency = job.Agency || from.Agency
+	if job.PostedAt.IsZero() || (!from.PostedAt.IsZero() && from.PostedAt.Before(job.PostedAt)) {
+		if !from.PostedAt.IsZero() {
+			job.PostedAt = from.PostedAt
+			job.PostedAtRaw = from.PostedAtRaw
+			job.PostedAtPrecision = from.PostedAtPrecision
+		}
+	}
+}
+
+func fillString(target *string, value string) {
+	if strings.TrimSpace(*target) == "" {
+		*target = value
+	}
+}
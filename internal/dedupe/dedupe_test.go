@@ -0,0 +1,95 @@
+package dedupe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/location"
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	cases := map[string]string{
+		"Software Engineer (m/w/d)":           "software engineer",
+		"Software Engineer m/f/d":             "software engineer",
+		"Softwareentwickler*in (all genders)": "softwareentwickler",
+		"Data Scientist (w/m/x) - Berlin":     "data scientist berlin",
+		"Ingénieur DevOps H/F":                "ingénieur devops",
+		"C++ Developer (gn)":                  "c++ developer",
+		"Senior M&A Analyst":                  "senior m a analyst",
+	}
+	for input, want := range cases {
+		if got := NormalizeTitle(input); got != want {
+			t.Fatalf("NormalizeTitle(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeCompany(t *testing.T) {
+	cases := map[string]string{
+		"ACME GmbH":                        "acme",
+		"ACME":                             "acme",
+		"Acme Inc.":                        "acme",
+		"Foo Bar GmbH & Co. KG":            "foo bar",
+		"Example S.A.":                     "example",
+		"Beispiel UG (haftungsbeschränkt)": "beispiel",
+	}
+	for input, want := range cases {
+		if got := NormalizeCompany(input); got != want {
+			t.Fatalf("NormalizeCompany(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeCompanyName(t *testing.T) {
+	cases := map[string]string{
+		"ACME GmbH":                        "acme",
+		"Foo Bar GmbH & Co. KG":            "foo bar",
+		"Beispiel UG (haftungsbeschränkt)": "beispiel",
+		"AS Roma":                          "as roma",
+		"The Coca Company Group":           "the coca company group",
+		"Company":                          "company",
+	}
+	for input, want := range cases {
+		if got := NormalizeCompanyName(input); got != want {
+			t.Fatalf("NormalizeCompanyName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	posted := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{Site: "indeed", Title: "Software Engineer m/f/d", Company: "ACME", Location: "München", URL: "https://indeed.example/1", Queries: []string{"software engineer"}},
+		{Site: "linkedin", Title: "Software Engineer (m/w/d)", Company: "ACME GmbH", Location: "Munich, Bavaria, Germany", URL: "https://linkedin.example/1", Salary: "60k EUR", PostedAt: posted, Queries: []string{"entwickler", "software engineer"}},
+		{Site: "linkedin", Title: "Software Engineer (m/w/d)", Company: "ACME GmbH", Location: "Munich", URL: "https://linkedin.example/2"},
+		{Site: "stepstone", Title: "Software Engineer (m/w/d)", Company: "ACME GmbH", Location: "Hamburg", URL: "https://stepstone.example/1"},
+		{Site: "stepstone", Title: "Senior Software Engineer (m/w/d)", Company: "ACME GmbH", Location: "München", URL: "https://stepstone.example/2"},
+	}
+	for i := range jobs {
+		location.Apply(&jobs[i], "de")
+	}
+
+	got, stats := Merge(jobs)
+	if len(got) != 4 {
+		t.Fatalf("Merge() returned %d jobs, want 4: %+v", len(got), got)
+	}
+	if queries := strings.Join(got[0].Queries, ","); queries != "software engineer,entwickler" {
+		t.Fatalf("merged queries = %q, want software engineer,entwickler", queries)
+	}
+	if stats.Merged != 1 || stats.Clusters != 1 || stats.Total != 5 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	primary := got[0]
+	if primary.Site != "indeed" || primary.Salary != "60k EUR" || !primary.PostedAt.Equal(posted) {
+		t.Fatalf("primary not filled from duplicate: %+v", primary)
+	}
+	if len(primary.Sources) != 2 || primary.Sources[0].Site != "indeed" || primary.Sources[1].URL != "https://linkedin.example/1" {
+		t.Fatalf("unexpected sources: %+v", primary.Sources)
+	}
+	if got[1].URL != "https://linkedin.example/2" {
+		t.Fatalf("second LinkedIn posting should stay separate, got %+v", got[1])
+	}
+}
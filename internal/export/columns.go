@@ -0,0 +1,158 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// csvColumns is the default CSV/TSV column set.
+var csvColumns = []string{
+	"site",
+	"id",
+	"title",
+	"company",
+	"location",
+	"city",
+	"region",
+	"country_code",
+	"latitude",
+	"longitude",
+	"url",
+	"remote",
+	"work_arrangement",
+	"job_type",
+	"employment_type",
+	"seniority",
+	"salary",
+	"salary_min",
+	"salary_max",
+	"salary_currency",
+	"salary_period",
+	"salary_annual",
+	"snippet",
+	"posted_at",
+	"posted_at_raw",
+	"repost",
+	"agency",
+	"status",
+	"status_note",
+	"queries",
+	"query_hits",
+}
+
+// tableColumns is the default table column set; status is added when some
+// job is tracked.
+var tableColumns = []string{"site", "title", "company", "url"}
+
+// columnValues renders each selectable column of a job as text.
+var columnValues = map[string]func(models.Job) string{
+	"site":                 func(j models.Job) string { return j.Site },
+	"id":                   func(j models.Job) string { return j.ID },
+	"title":                func(j models.Job) string { return j.Title },
+	"company":              func(j models.Job) string { return j.Company },
+	"location":             func(j models.Job) string { return j.Location },
+	"city":                 func(j models.Job) string { return j.City },
+	"region":               func(j models.Job) string { return j.Region },
+	"country_code":         func(j models.Job) string { return j.CountryCode },
+	"latitude":             func(j models.Job) string { return floatString(j.Latitude) },
+	"longitude":            func(j models.Job) string { return floatString(j.Longitude) },
+	"url":                  func(j models.Job) string { return j.URL },
+	"remote":               func(j models.Job) string { return boolString(j.Remote) },
+	"work_arrangement":     func(j models.Job) string { return j.WorkArrangement },
+	"job_type":             func(j models.Job) string { return j.JobType },
+	"employment_type":      func(j models.Job) string { return j.EmploymentType },
+	"seniority":            func(j models.Job) string { return j.Seniority },
+	"salary":               func(j models.Job) string { return j.Salary },
+	"salary_min":           func(j models.Job) string { return floatString(j.SalaryMin) },
+	"salary_max":           func(j models.Job) string { return floatString(j.SalaryMax) },
+	"salary_currency":      func(j models.Job) string { return j.SalaryCurrency },
+	"salary_period":        func(j models.Job) string { return j.SalaryPeriod },
+	"salary_annual":        func(j models.Job) string { return floatString(j.SalaryAnnual) },
+	"description":          func(j models.Job) string { return j.Description },
+	"description_markdown": func(j models.Job) string { return j.DescriptionMarkdown },
+	"description_html":     func(j models.Job) string { return j.DescriptionHTML },
+	"snippet":              func(j models.Job) string { return j.Snippet },
+	"posted_at":            func(j models.Job) string { return timeString(j.PostedAt) },
+	"posted_at_raw":        func(j models.Job) string { return j.PostedAtRaw },
+	"posted_at_precision":  func(j models.Job) string { return j.PostedAtPrecision },
+	"sources":              sourcesString,
+	"queries":              func(j models.Job) string { return strings.Join(j.Queries, ",") },
+	"query_hits":           queryHitsString,
+	"first_seen":           func(j models.Job) string { return timeString(j.FirstSeen) },
+	"last_seen":            func(j models.Job) string { return timeString(j.LastSeen) },
+	"times_seen":           func(j models.Job) string { return intString(j.TimesSeen) },
+	"repost":               func(j models.Job) string { return boolString(j.Repost) },
+	"agency":               func(j models.Job) string { return boolString(j.Agency) },
+	"status":               func(j models.Job) string { return j.Status },
+	"status_note":          func(j models.Job) string { return j.StatusNote },
+}
+
+// Columns returns the names accepted by ParseColumns, sorted.
+func Columns() []string {
+	names := make([]string, 0, len(columnValues))
+	for name := range columnValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseColumns splits a comma-separated column list and checks every name.
+func ParseColumns(value string) ([]string, error) {
+	var columns []string
+	for _, part := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		if _, ok := columnValues[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(Columns(), ", "))
+		}
+		columns = append(columns, name)
+	}
+	return columns, nil
+}
+
+func columnRow(job models.Job, columns []string) []string {
+	row := make([]string, len(columns))
+	for i, name := range columns {
+		row[i] = columnValues[name](job)
+	}
+	return row
+}
+
+func timeString(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+func intString(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+// queryHitsString renders query hits as "site:query" pairs.
+func queryHitsString(job models.Job) string {
+	hits := make([]string, 0, len(job.QueryHits))
+	for _, hit := range job.QueryHits {
+		hits = append(hits, safe(hit.Site)+":"+safe(hit.Query))
+	}
+	return strings.Join(hits, ",")
+}
+
+func sourcesString(job models.Job) string {
+	sites := make([]string, 0, len(job.Sources))
+	for _, source := range job.Sources {
+		sites = append(sites, safe(source.Site))
+	}
+	return strings.Join(sites, ",")
+}
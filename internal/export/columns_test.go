@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestWriteJobsColumns(t *testing.T) {
+	jobs := []models.Job{{
+		Site: "linkedin", Title: "SRE", Company: "Acme", Location: "Berlin",
+		URL: "https://example.com/1", PostedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	}}
+
+	var csv bytes.Buffer
+	if err := WriteJobs(&csv, jobs, FormatCSV, WriteOptions{Columns: []string{"title", "location", "posted_at", "salary"}}); err != nil {
+		t.Fatalf("WriteJobs(csv) error = %v", err)
+	}
+	if want := "title,location,posted_at,salary\nSRE,Berlin,2026-10-01T09:00:00Z,\n"; csv.String() != want {
+		t.Fatalf("csv = %q, want %q", csv.String(), want)
+	}
+
+	var table bytes.Buffer
+	if err := WriteJobs(&table, jobs, FormatTable, WriteOptions{Columns: []string{"title", "salary", "url"}}); err != nil {
+		t.Fatalf("WriteJobs(table) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != 2 || strings.Join(strings.Fields(lines[1]), " ") != "SRE - https://example.com/1" {
+		t.Fatalf("table = %q, want title, dash and url", table.String())
+	}
+
+	var defaults bytes.Buffer
+	if err := WriteJobs(&defaults, jobs, FormatTable, WriteOptions{}); err != nil {
+		t.Fatalf("WriteJobs(table) error = %v", err)
+	}
+	if header := strings.Fields(strings.SplitN(defaults.String(), "\n", 2)[0]); strings.Join(header, ",") != "site,title,company,url" {
+		t.Fatalf("default table header = %v", header)
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	got, err := ParseColumns(" Site,title,, posted_at ")
+	if err != nil || strings.Join(got, ",") != "site,title,posted_at" {
+		t.Fatalf("ParseColumns() = %v, %v", got, err)
+	}
+	if _, err := ParseColumns("site,colour"); err == nil {
+		t.Fatalf("ParseColumns(colour) error = nil, want error")
+	}
+	for _, name := range csvColumns {
+		if _, ok := columnValues[name]; !ok {
+			t.Fatalf("default CSV column %q has no value", name)
+		}
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 	"time"
 
 	"github.com/jimezsa/jobcli/internal/models"
@@ -21,14 +23,32 @@ const (
 	FormatTable    Format = "table"
 	FormatCSV      Format = "csv"
 	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
 	FormatMarkdown Format = "md"
 	FormatTSV      Format = "tsv"
+	FormatHTML     Format = "html"
+	FormatRSS      Format = "rss"
+	FormatAtom     Format = "atom"
+	FormatXLSX     Format = "xlsx"
+	FormatODS      Format = "ods"
 )
 
 type WriteOptions struct {
-	ColorEnabled bool
-	Hyperlinks   bool
-	LinkStyle    LinkStyle
+	ColorEnabled      bool
+	Hyperlinks        bool
+	LinkStyle         LinkStyle
+	DescriptionFormat DescriptionFormat
+	// IsNew reports whether a job is missing from the seen history; nil when
+	// no history was given. The HTML report highlights new jobs with it.
+	IsNew func(models.Job) bool
+	// FeedTitle names RSS and Atom feeds.
+	FeedTitle string
+	// Columns replaces the default table, CSV and TSV columns.
+	Columns []string
+	// Template, when set, renders the jobs instead of format.
+	Template *template.Template
+	// GroupBy splits table and Markdown output into one section per group.
+	GroupBy GroupBy
 }
 
 type LinkStyle string
@@ -38,35 +58,89 @@ const (
 	LinkStyleFull  LinkStyle = "full"
 )
 
+// DescriptionFormat selects which description representation is exported.
+type DescriptionFormat string
+
+const (
+	DescriptionPlain    DescriptionFormat = "plain"
+	DescriptionMarkdown DescriptionFormat = "markdown"
+	DescriptionHTML     DescriptionFormat = "html"
+)
+
 func WriteJobs(w io.Writer, jobs []models.Job, format Format, opts WriteOptions) error {
+	if IsBinary(format) || format == FormatHTML {
+		// The report and spreadsheets show descriptions as text; posting
+		// markup would only appear as tags.
+		if opts.DescriptionFormat == DescriptionHTML {
+			opts.DescriptionFormat = DescriptionPlain
+		}
+	}
+	source := jobs
+	jobs = applyDescriptionFormat(jobs, opts.DescriptionFormat)
+	if opts.Template != nil {
+		return opts.Template.Execute(w, jobs)
+	}
 	switch format {
 	case FormatJSON:
 		return writeJSON(w, jobs)
+	case FormatNDJSON:
+		return writeNDJSON(w, jobs)
 	case FormatCSV:
-		return writeCSV(w, jobs, ',')
+		return writeCSV(w, jobs, ',', opts.Columns)
 	case FormatTSV:
-		return writeCSV(w, jobs, '\t')
+		return writeCSV(w, jobs, '\t', opts.Columns)
 	case FormatMarkdown:
-		return writeMarkdown(w, jobs)
+		return writeMarkdown(w, jobs, opts)
+	case FormatHTML:
+		return writeHTML(w, jobs, opts)
+	case FormatRSS:
+		return writeRSS(w, jobs, opts)
+	case FormatAtom:
+		// writeAtom applies the description format itself.
+		return writeAtom(w, source, opts)
+	case FormatXLSX:
+		return writeXLSX(w, jobs, opts)
+	case FormatODS:
+		return writeODS(w, jobs, opts)
 	default:
 		return writeTable(w, jobs, opts)
 	}
 }
 
+// IsBinary reports whether format produces a binary file rather than text.
+func IsBinary(format Format) bool {
+	return format == FormatXLSX || format == FormatODS
+}
+
 func writeJSON(w io.Writer, jobs []models.Job) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(jobs)
 }
 
-func writeCSV(w io.Writer, jobs []models.Job, delim rune) error {
+// writeNDJSON writes one compact JSON object per line. It has no header or
+// footer, so callers may write a result set in several calls as it arrives.
+func writeNDJSON(w io.Writer, jobs []models.Job) error {
+	enc := json.NewEncoder(w)
+	for _, job := range jobs {
+		if err := enc.Encode(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, jobs []models.Job, delim rune, columns []string) error {
+	if len(columns) == 0 {
+		columns = csvColumns
+	}
 	writer := csv.NewWriter(w)
 	writer.Comma = delim
-	if err := writer.Write(csvHeader()); err != nil {
+	if err := writer.Write(columns); err != nil {
 		return err
 	}
 	for _, job := range jobs {
-		if err := writer.Write(csvRow(job)); err != nil {
+		if err := writer.Write(columnRow(job, columns)); err != nil {
 			return err
 		}
 	}
@@ -75,20 +149,60 @@ func writeCSV(w io.Writer, jobs []models.Job, delim rune) error {
 }
 
 func writeTable(w io.Writer, jobs []models.Job, opts WriteOptions) error {
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = tableColumns
+		// The status column only appears once some job is tracked.
+		if hasStatus(jobs) {
+			columns = append(columns[:len(columns):len(columns)], "status")
+		}
+	}
+	if opts.GroupBy == GroupNone {
+		return writeTableRows(w, jobs, opts, columns)
+	}
+	for i, group := range groupJobs(jobs, opts.GroupBy) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s: %s (%d)\n", opts.GroupBy, group.name, len(group.jobs))
+		if err := writeTableRows(w, group.jobs, opts, columns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTableRows(w io.Writer, jobs []models.Job, opts WriteOptions, columns []string) error {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
-	fmt.Fprintln(tw, strings.Join(tableHeader(), "\t"))
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
 	output := termenv.NewOutput(w)
 	for _, job := range jobs {
-		fmt.Fprintln(tw, strings.Join(tableRow(job, output, opts), "\t"))
+		fmt.Fprintln(tw, strings.Join(tableRow(job, output, opts, columns), "\t"))
 	}
 	return tw.Flush()
 }
 
-func writeMarkdown(w io.Writer, jobs []models.Job) error {
+func writeMarkdown(w io.Writer, jobs []models.Job, opts WriteOptions) error {
 	if len(jobs) == 0 {
 		_, err := fmt.Fprintln(w, "No results.")
 		return err
 	}
+	if opts.GroupBy == GroupNone {
+		return writeMarkdownItems(w, jobs, opts)
+	}
+	for i, group := range groupJobs(jobs, opts.GroupBy) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "## %s (%d)\n\n", group.name, len(group.jobs))
+		if err := writeMarkdownItems(w, group.jobs, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarkdownItems(w io.Writer, jobs []models.Job, opts WriteOptions) error {
 	for _, job := range jobs {
 		urlLine := "  URL: -"
 		if url := safe(job.URL); url != "" {
@@ -100,12 +214,17 @@ func writeMarkdown(w io.Writer, jobs []models.Job) error {
 			fmt.Sprintf("  Site: %s", safe(job.Site)),
 			urlLine,
 		}
-		if job.Remote {
+		if job.WorkArrangement != "" && job.WorkArrangement != models.WorkArrangementUnknown {
+			lines = append(lines, fmt.Sprintf("  Arrangement: %s", safe(job.WorkArrangement)))
+		} else if job.Remote {
 			lines = append(lines, "  Remote: yes")
 		}
 		if job.JobType != "" {
 			lines = append(lines, fmt.Sprintf("  Type: %s", safe(job.JobType)))
 		}
+		if job.Seniority != "" {
+			lines = append(lines, fmt.Sprintf("  Seniority: %s", safe(job.Seniority)))
+		}
 		if job.Salary != "" {
 			lines = append(lines, fmt.Sprintf("  Salary: %s", safe(job.Salary)))
 		}
@@ -115,9 +234,37 @@ func writeMarkdown(w io.Writer, jobs []models.Job) error {
 		if job.PostedAtRaw != "" {
 			lines = append(lines, fmt.Sprintf("  Posted (raw): %s", safe(job.PostedAtRaw)))
 		}
+		if job.Repost {
+			lines = append(lines, "  Repost: yes")
+		}
+		if job.Agency {
+			lines = append(lines, "  Agency: yes")
+		}
+		if job.Status != "" {
+			status := safe(job.Status)
+			if note := safe(job.StatusNote); note != "" {
+				status += " (" + note + ")"
+			}
+			lines = append(lines, fmt.Sprintf("  Status: %s", status))
+		}
 		if job.Snippet != "" {
 			lines = append(lines, fmt.Sprintf("  Summary: %s", safe(job.Snippet)))
 		}
+		if len(job.Queries) > 0 {
+			lines = append(lines, fmt.Sprintf("  Queries: %s", strings.Join(job.Queries, ", ")))
+		}
+		if len(job.Sources) > 1 {
+			sites := make([]string, 0, len(job.Sources))
+			for _, source := range job.Sources {
+				sites = append(sites, safe(source.Site))
+			}
+			lines = append(lines, fmt.Sprintf("  Sources: %s", strings.Join(sites, ", ")))
+		}
+		if opts.DescriptionFormat == DescriptionMarkdown && safe(job.Description) != "" {
+			lines = append(lines, "  Description:", "")
+			lines = append(lines, indentLines(safe(job.Description), "    ")...)
+			lines = append(lines, "")
+		}
 		for _, line := range lines {
 			if _, err := fmt.Fprintln(w, line); err != nil {
 				return err
@@ -127,40 +274,43 @@ func writeMarkdown(w io.Writer, jobs []models.Job) error {
 	return nil
 }
 
-func csvHeader() []string {
-	return []string{
-		"site",
-		"title",
-		"company",
-		"location",
-		"url",
-		"remote",
-		"job_type",
-		"salary",
-		"snippet",
-		"posted_at",
-		"posted_at_raw",
+// applyDescriptionFormat returns copies of jobs whose Description holds the
+// requested markdown or HTML representation, falling back to plain text when
+// it is missing; the alternate representation fields are then cleared so
+// exports carry one copy. Plain output keeps every representation.
+func applyDescriptionFormat(jobs []models.Job, format DescriptionFormat) []models.Job {
+	if format != DescriptionMarkdown && format != DescriptionHTML {
+		return jobs
 	}
+	out := make([]models.Job, len(jobs))
+	for i, job := range jobs {
+		switch format {
+		case DescriptionMarkdown:
+			if job.DescriptionMarkdown != "" {
+				job.Description = job.DescriptionMarkdown
+			}
+		case DescriptionHTML:
+			if job.DescriptionHTML != "" {
+				job.Description = job.DescriptionHTML
+			}
+		}
+		job.DescriptionMarkdown = ""
+		job.DescriptionHTML = ""
+		out[i] = job
+	}
+	return out
 }
 
-func csvRow(job models.Job) []string {
-	posted := ""
-	if !job.PostedAt.IsZero() {
-		posted = job.PostedAt.Format(time.RFC3339)
-	}
-	return []string{
-		job.Site,
-		job.Title,
-		job.Company,
-		job.Location,
-		job.URL,
-		boolString(job.Remote),
-		job.JobType,
-		job.Salary,
-		job.Snippet,
-		posted,
-		job.PostedAtRaw,
+func indentLines(value string, prefix string) []string {
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = prefix + line
 	}
+	return lines
 }
 
 func boolString(value bool) string {
@@ -170,38 +320,55 @@ func boolString(value bool) string {
 	return "false"
 }
 
+func floatString(value float64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
 func safe(value string) string {
 	return strings.TrimSpace(value)
 }
 
-func tableHeader() []string {
-	return []string{
-		"site",
-		"title",
-		"company",
-		"url",
+func hasStatus(jobs []models.Job) bool {
+	for _, job := range jobs {
+		if job.Status != "" {
+			return true
+		}
 	}
+	return false
 }
 
-func tableRow(job models.Job, output *termenv.Output, opts WriteOptions) []string {
-	url := safe(job.URL)
-	displayURL := "-"
-	if url != "" {
-		displayURL = url
-		if opts.LinkStyle == LinkStyleShort && opts.Hyperlinks {
-			displayURL = shortURLLabel(url)
-		}
-		displayURL = ui.ColorizeLink(output, opts.ColorEnabled, displayURL)
-		if opts.Hyperlinks {
-			displayURL = hyperlink(url, displayURL)
+// tableRow renders columns for the terminal: URLs get link styling and
+// empty cells a dash.
+func tableRow(job models.Job, output *termenv.Output, opts WriteOptions, columns []string) []string {
+	row := columnRow(job, columns)
+	for i, name := range columns {
+		value := safe(row[i])
+		switch {
+		case value == "":
+			value = "-"
+		case name == "url":
+			value = tableURL(value, output, opts)
+		case name == "description" || name == "snippet":
+			value = strings.Join(strings.Fields(value), " ")
 		}
+		row[i] = value
+	}
+	return row
+}
+
+func tableURL(url string, output *termenv.Output, opts WriteOptions) string {
+	displayURL := url
+	if opts.LinkStyle == LinkStyleShort && opts.Hyperlinks {
+		displayURL = shortURLLabel(url)
 	}
-	return []string{
-		safe(job.Site),
-		safe(job.Title),
-		safe(job.Company),
-		displayURL,
+	displayURL = ui.ColorizeLink(output, opts.ColorEnabled, displayURL)
+	if opts.Hyperlinks {
+		displayURL = hyperlink(url, displayURL)
 	}
+	return displayURL
 }
 
 func hyperlink(url string, text string) string {
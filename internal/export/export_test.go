@@ -0,0 +1,44 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestWriteJobs_DescriptionFormat(t *testing.T) {
+	jobs := []models.Job{{
+		Title:               "SRE",
+		Description:         "Run things",
+		DescriptionMarkdown: "- Run things",
+		DescriptionHTML:     "<ul><li>Run things</li></ul>",
+	}}
+
+	decode := func(format DescriptionFormat) models.Job {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := WriteJobs(&buf, jobs, FormatJSON, WriteOptions{DescriptionFormat: format}); err != nil {
+			t.Fatalf("WriteJobs(%q) error = %v", format, err)
+		}
+		var got []models.Job
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		return got[0]
+	}
+
+	// Plain output carries the markdown and HTML alongside the plain text.
+	for _, format := range []DescriptionFormat{"", DescriptionPlain} {
+		if got := decode(format); got.Description != "Run things" || got.DescriptionMarkdown != "- Run things" || got.DescriptionHTML == "" {
+			t.Fatalf("%q description fields = %+v", format, got)
+		}
+	}
+	if got := decode(DescriptionMarkdown); got.Description != "- Run things" || got.DescriptionMarkdown != "" || got.DescriptionHTML != "" {
+		t.Fatalf("markdown description fields = %+v", got)
+	}
+	if jobs[0].Description != "Run things" {
+		t.Fatalf("WriteJobs() modified its input: %+v", jobs[0])
+	}
+}
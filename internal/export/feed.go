@@ -0,0 +1,273 @@
+package export
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+	"github.com/jimezsa/jobcli/internal/seen"
+)
+
+const (
+	defaultFeedTitle = "jobcli search results"
+	feedHomepage     = "https://github.com/jimezsa/jobcli"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	Description string  `xml:"description,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Updated   string      `xml:"updated"`
+	Link      atomLink    `xml:"link"`
+	Author    atomAuthor  `xml:"author"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title     string       `xml:"title"`
+	ID        string       `xml:"id"`
+	Updated   string       `xml:"updated"`
+	Published string       `xml:"published,omitempty"`
+	Link      *atomLink    `xml:"link,omitempty"`
+	Author    *atomAuthor  `xml:"author,omitempty"`
+	Category  *atomTerm    `xml:"category,omitempty"`
+	Content   *atomContent `xml:"content,omitempty"`
+}
+
+type atomTerm struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func writeRSS(w io.Writer, jobs []models.Job, opts WriteOptions) error {
+	title := feedTitle(opts)
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       title,
+			Link:        feedHomepage,
+			Description: title,
+			Generator:   "jobcli",
+			Items:       make([]rssItem, 0, len(jobs)),
+		},
+	}
+	if updated := feedUpdated(jobs); !updated.IsZero() {
+		feed.Channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+	for _, job := range jobs {
+		item := rssItem{
+			Title:       entryTitle(job),
+			Link:        safe(job.URL),
+			GUID:        rssGUID{Value: JobGUID(job)},
+			Category:    safe(job.Site),
+			Description: entryContent(job),
+		}
+		if published := jobPublished(job); !published.IsZero() {
+			item.PubDate = published.Format(time.RFC1123Z)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+	return writeXML(w, feed)
+}
+
+func writeAtom(w io.Writer, jobs []models.Job, opts WriteOptions) error {
+	title := feedTitle(opts)
+	// Atom requires <updated>; without any dated job the epoch keeps the
+	// output identical across runs.
+	updated := feedUpdated(jobs)
+	if updated.IsZero() {
+		updated = time.Unix(0, 0).UTC()
+	}
+	sum := sha1.Sum([]byte(title))
+	feed := atomFeed{
+		Title:     title,
+		ID:        "urn:jobcli:feed:" + hex.EncodeToString(sum[:]),
+		Updated:   updated.Format(time.RFC3339),
+		Link:      atomLink{Href: feedHomepage},
+		Author:    atomAuthor{Name: "jobcli"},
+		Generator: "jobcli",
+		Entries:   make([]atomEntry, 0, len(jobs)),
+	}
+	// Content is html only for jobs with an HTML description; the others
+	// fall back to plain text.
+	formatted := applyDescriptionFormat(jobs, opts.DescriptionFormat)
+	for i, job := range formatted {
+		content := &atomContent{Type: "text", Value: entryContent(job)}
+		if opts.DescriptionFormat == DescriptionHTML && jobs[i].DescriptionHTML != "" {
+			content = &atomContent{Type: "html", Value: entryHTMLContent(job)}
+		}
+		entry := atomEntry{
+			Title:   entryTitle(job),
+			ID:      JobGUID(job),
+			Updated: updated.Format(time.RFC3339),
+			Content: content,
+		}
+		if published := jobPublished(job); !published.IsZero() {
+			entry.Published = published.Format(time.RFC3339)
+			entry.Updated = entry.Published
+		}
+		if link := safe(job.URL); link != "" {
+			entry.Link = &atomLink{Href: link, Rel: "alternate"}
+		}
+		if company := safe(job.Company); company != "" {
+			entry.Author = &atomAuthor{Name: company}
+		}
+		if site := safe(job.Site); site != "" {
+			entry.Category = &atomTerm{Term: site}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return writeXML(w, feed)
+}
+
+func writeXML(w io.Writer, value any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// JobGUID returns a feed identifier that stays the same across runs: the
+// site and its job ID when known, otherwise a hash of the job's seen key.
+func JobGUID(job models.Job) string {
+	site := strings.ToLower(safe(job.Site))
+	if id := safe(job.ID); id != "" && site != "" {
+		return "urn:jobcli:" + url.PathEscape(site) + ":" + url.PathEscape(id)
+	}
+	key, ok := seen.Key(job)
+	if !ok {
+		key = site + "|" + safe(job.Title) + "|" + safe(job.Company)
+	}
+	sum := sha1.Sum([]byte(key))
+	return "urn:jobcli:key:" + hex.EncodeToString(sum[:])
+}
+
+func feedTitle(opts WriteOptions) string {
+	if title := strings.TrimSpace(opts.FeedTitle); title != "" {
+		return title
+	}
+	return defaultFeedTitle
+}
+
+// feedUpdated is the newest publication date among jobs, or zero when none
+// is dated.
+func feedUpdated(jobs []models.Job) time.Time {
+	var latest time.Time
+	for _, job := range jobs {
+		if published := jobPublished(job); published.After(latest) {
+			latest = published
+		}
+	}
+	return latest
+}
+
+// jobPublished prefers the posting date and falls back to when the seen
+// history first saw the job.
+func jobPublished(job models.Job) time.Time {
+	if !job.PostedAt.IsZero() {
+		return job.PostedAt.UTC()
+	}
+	return job.FirstSeen.UTC()
+}
+
+func entryTitle(job models.Job) string {
+	title := safe(job.Title)
+	if company := safe(job.Company); company != "" {
+		title = fmt.Sprintf("%s at %s", title, company)
+	}
+	return title
+}
+
+// entryContent leads with location and salary, followed by the description
+// or snippet.
+func entryContent(job models.Job) string {
+	body := safe(job.Description)
+	if body == "" {
+		body = safe(job.Snippet)
+	}
+	lines := entryHeader(job)
+	if body == "" {
+		return lines
+	}
+	if lines == "" {
+		return body
+	}
+	return lines + "\n\n" + body
+}
+
+// entryHTMLContent is entryContent for a job whose description is HTML: the
+// plain-text header is escaped into its own paragraph.
+func entryHTMLContent(job models.Job) string {
+	lines := entryHeader(job)
+	if lines == "" {
+		return job.Description
+	}
+	return "<p>" + html.EscapeString(lines) + "</p>\n" + job.Description
+}
+
+// entryHeader joins the job's location, salary and status.
+func entryHeader(job models.Job) string {
+	var header []string
+	for _, value := range []string{safe(job.Location), safe(job.Salary), safe(job.Status)} {
+		if value != "" {
+			header = append(header, value)
+		}
+	}
+	return strings.Join(header, " · ")
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestWriteFeeds(t *testing.T) {
+	posted := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{Site: "linkedin", ID: "123", Title: "SRE", Company: "Acme & Co", URL: "https://example.com/1", PostedAt: posted, Description: "Run <things>"},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", Snippet: "Pipelines"},
+	}
+
+	var rss bytes.Buffer
+	if err := WriteJobs(&rss, jobs, FormatRSS, WriteOptions{FeedTitle: "jobcli: sre"}); err != nil {
+		t.Fatalf("WriteJobs(rss) error = %v", err)
+	}
+	var parsed rssFeed
+	if err := xml.Unmarshal(rss.Bytes(), &parsed); err != nil {
+		t.Fatalf("rss is not valid XML: %v", err)
+	}
+	if parsed.Channel.Title != "jobcli: sre" || len(parsed.Channel.Items) != 2 {
+		t.Fatalf("channel = %+v, want title and 2 items", parsed.Channel)
+	}
+	item := parsed.Channel.Items[0]
+	if item.Title != "SRE at Acme & Co" || item.GUID.Value != "urn:jobcli:linkedin:123" || item.GUID.IsPermaLink {
+		t.Fatalf("item = %+v, want title and site:id guid", item)
+	}
+	if item.PubDate != "Thu, 01 Oct 2026 09:30:00 +0000" || item.Description != "Run <things>" {
+		t.Fatalf("item = %+v, want pubDate and description", item)
+	}
+	if parsed.Channel.Items[1].PubDate != "" || parsed.Channel.Items[1].Description != "Pipelines" {
+		t.Fatalf("item = %+v, want no pubDate and snippet", parsed.Channel.Items[1])
+	}
+
+	var atom bytes.Buffer
+	if err := WriteJobs(&atom, jobs, FormatAtom, WriteOptions{}); err != nil {
+		t.Fatalf("WriteJobs(atom) error = %v", err)
+	}
+	out := atom.String()
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		"<title>jobcli search results</title>",
+		"<updated>2026-10-01T09:30:00Z</updated>",
+		"<id>urn:jobcli:linkedin:123</id>",
+		`<link href="https://example.com/1" rel="alternate"></link>`,
+		`<content type="text">Run &lt;things&gt;</content>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("atom missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteFeeds_UndatedJobsAreStable(t *testing.T) {
+	firstSeen := time.Date(2026, 9, 15, 8, 0, 0, 0, time.UTC)
+	seenJobs := []models.Job{{Site: "indeed", ID: "1", Title: "SRE", FirstSeen: firstSeen}}
+	var rss bytes.Buffer
+	if err := WriteJobs(&rss, seenJobs, FormatRSS, WriteOptions{}); err != nil {
+		t.Fatalf("WriteJobs(rss) error = %v", err)
+	}
+	for _, want := range []string{
+		"<lastBuildDate>Tue, 15 Sep 2026 08:00:00 +0000</lastBuildDate>",
+		"<pubDate>Tue, 15 Sep 2026 08:00:00 +0000</pubDate>",
+	} {
+		if !strings.Contains(rss.String(), want) {
+			t.Fatalf("rss missing %q:\n%s", want, rss.String())
+		}
+	}
+
+	undated := []models.Job{{Site: "indeed", ID: "2", Title: "Data Engineer"}}
+	rss.Reset()
+	if err := WriteJobs(&rss, undated, FormatRSS, WriteOptions{}); err != nil {
+		t.Fatalf("WriteJobs(rss) error = %v", err)
+	}
+	if strings.Contains(rss.String(), "lastBuildDate") || strings.Contains(rss.String(), "pubDate") {
+		t.Fatalf("rss has dates for undated jobs:\n%s", rss.String())
+	}
+	var atom bytes.Buffer
+	if err := WriteJobs(&atom, undated, FormatAtom, WriteOptions{}); err != nil {
+		t.Fatalf("WriteJobs(atom) error = %v", err)
+	}
+	if got := strings.Count(atom.String(), "<updated>1970-01-01T00:00:00Z</updated>"); got != 2 {
+		t.Fatalf("atom has %d epoch <updated> elements, want 2:\n%s", got, atom.String())
+	}
+}
+
+func TestWriteAtom_HTMLContent(t *testing.T) {
+	jobs := []models.Job{
+		{Site: "linkedin", ID: "1", Title: "SRE", Location: "R&D <Munich>", Description: "Run things", DescriptionHTML: "<ul><li>Run things</li></ul>"},
+		{Site: "indeed", ID: "2", Title: "Data Engineer", Location: "Berlin", Description: "Build <pipelines> & more"},
+	}
+	var atom bytes.Buffer
+	if err := WriteJobs(&atom, jobs, FormatAtom, WriteOptions{DescriptionFormat: DescriptionHTML}); err != nil {
+		t.Fatalf("WriteJobs(atom) error = %v", err)
+	}
+	var parsed atomFeed
+	if err := xml.Unmarshal(atom.Bytes(), &parsed); err != nil {
+		t.Fatalf("atom is not valid XML: %v", err)
+	}
+	if len(parsed.Entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(parsed.Entries))
+	}
+	html := parsed.Entries[0].Content
+	if html.Type != "html" || html.Value != "<p>R&amp;D &lt;Munich&gt;</p>\n<ul><li>Run things</li></ul>" {
+		t.Fatalf("html content = %+v, want escaped header and HTML description", html)
+	}
+	text := parsed.Entries[1].Content
+	if text.Type != "text" || text.Value != "Berlin\n\nBuild <pipelines> & more" {
+		t.Fatalf("plain content = %+v, want text without an HTML description", text)
+	}
+}
+
+func TestJobGUIDStable(t *testing.T) {
+	job := models.Job{Site: "indeed", Title: "Data Engineer", Company: "Beta", URL: "https://example.com/a"}
+	moved := job
+	moved.URL = "https://example.com/b"
+	if JobGUID(job) != JobGUID(moved) {
+		t.Fatalf("JobGUID() changed with the URL")
+	}
+	if !strings.HasPrefix(JobGUID(job), "urn:jobcli:key:") {
+		t.Fatalf("JobGUID() = %q, want key hash", JobGUID(job))
+	}
+	other := job
+	other.Title = "Analyst"
+	if JobGUID(job) == JobGUID(other) {
+		t.Fatalf("JobGUID() collided for different jobs")
+	}
+}
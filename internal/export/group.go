@@ -0,0 +1,69 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// GroupBy selects how table and Markdown output is split into sections.
+type GroupBy string
+
+const (
+	GroupNone    GroupBy = ""
+	GroupSite    GroupBy = "site"
+	GroupCompany GroupBy = "company"
+	GroupQuery   GroupBy = "query"
+)
+
+// ParseGroupBy parses a --group-by value.
+func ParseGroupBy(value string) (GroupBy, error) {
+	switch group := GroupBy(strings.ToLower(strings.TrimSpace(value))); group {
+	case GroupNone, GroupSite, GroupCompany, GroupQuery:
+		return group, nil
+	default:
+		return "", fmt.Errorf("unknown group %q (want site, company or query)", value)
+	}
+}
+
+type jobGroup struct {
+	name string
+	jobs []models.Job
+}
+
+// groupJobs splits jobs into groups in order of first appearance, so sorted
+// input gives sorted groups. Company groups ignore case and keep the first
+// spelling. A job returned by several queries is listed under each of them.
+func groupJobs(jobs []models.Job, by GroupBy) []jobGroup {
+	var groups []jobGroup
+	index := map[string]int{}
+	for _, job := range jobs {
+		var names []string
+		switch by {
+		case GroupCompany:
+			names = []string{safe(job.Company)}
+		case GroupQuery:
+			names = job.Queries
+		default:
+			names = []string{strings.ToLower(safe(job.Site))}
+		}
+		if len(names) == 0 {
+			names = []string{""}
+		}
+		for _, name := range names {
+			if name = safe(name); name == "" {
+				name = "unknown"
+			}
+			key := strings.ToLower(name)
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, jobGroup{name: name})
+			}
+			groups[i].jobs = append(groups[i].jobs, job)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestWriteJobsGroupBy(t *testing.T) {
+	jobs := []models.Job{
+		{Site: "linkedin", Title: "SRE", Company: "Acme"},
+		{Site: "indeed", Title: "Data Engineer", Company: "beta"},
+		{Site: "indeed", Title: "Analyst", Company: "ACME"},
+	}
+
+	var table bytes.Buffer
+	if err := WriteJobs(&table, jobs, FormatTable, WriteOptions{GroupBy: GroupCompany}); err != nil {
+		t.Fatalf("WriteJobs(table) error = %v", err)
+	}
+	out := table.String()
+	if !strings.HasPrefix(out, "company: Acme (2)\n") || !strings.Contains(out, "\n\ncompany: beta (1)\n") {
+		t.Fatalf("grouped table = %q", out)
+	}
+	if strings.Index(out, "Analyst") > strings.Index(out, "beta (1)") {
+		t.Fatalf("grouped table does not keep ACME jobs together:\n%s", out)
+	}
+
+	var md bytes.Buffer
+	if err := WriteJobs(&md, jobs, FormatMarkdown, WriteOptions{GroupBy: GroupSite}); err != nil {
+		t.Fatalf("WriteJobs(md) error = %v", err)
+	}
+	if out := md.String(); !strings.HasPrefix(out, "## linkedin (1)\n\n- **SRE** (Acme)") || !strings.Contains(out, "\n## indeed (2)\n\n") {
+		t.Fatalf("grouped markdown = %q", out)
+	}
+
+	queried := []models.Job{
+		{Site: "linkedin", Title: "SRE", Company: "Acme", Queries: []string{"sre", "devops"}},
+		{Site: "indeed", Title: "Platform Engineer", Company: "Beta", Queries: []string{"devops"}},
+		{Site: "google", Title: "Analyst", Company: "Gamma"},
+	}
+	var byQuery bytes.Buffer
+	if err := WriteJobs(&byQuery, queried, FormatTable, WriteOptions{GroupBy: GroupQuery}); err != nil {
+		t.Fatalf("WriteJobs(table) error = %v", err)
+	}
+	out = byQuery.String()
+	for _, want := range []string{"query: sre (1)\n", "\n\nquery: devops (2)\n", "\n\nquery: unknown (1)\n"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("query groups missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package export
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+//go:embed report.html
+var reportSource string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportSource))
+
+type reportData struct {
+	Total    int
+	New      int
+	WithSeen bool
+	Sites    []reportSite
+	Rows     []reportRow
+}
+
+type reportSite struct {
+	Name  string
+	Class string
+	Count int
+}
+
+type reportRow struct {
+	Site        string
+	SiteClass   string
+	Title       string
+	URL         string
+	Company     string
+	Location    string
+	Salary      string
+	SalarySort  string
+	Posted      string
+	PostedSort  string
+	New         bool
+	Status      string
+	Details     string
+	Description string
+}
+
+// writeHTML writes a single self-contained HTML page: summary counts per site
+// and a sortable, filterable table with expandable descriptions. Descriptions
+// are always shown as text; posting HTML is never embedded.
+func writeHTML(w io.Writer, jobs []models.Job, opts WriteOptions) error {
+	data := reportData{
+		Total:    len(jobs),
+		WithSeen: opts.IsNew != nil,
+		Rows:     make([]reportRow, 0, len(jobs)),
+	}
+
+	siteTotals := map[string]int{}
+	for _, job := range jobs {
+		site := strings.ToLower(safe(job.Site))
+		if site == "" {
+			site = "unknown"
+		}
+		siteTotals[site]++
+
+		row := reportRow{
+			Site:        site,
+			SiteClass:   siteClass(site),
+			Title:       safe(job.Title),
+			URL:         safe(job.URL),
+			Company:     safe(job.Company),
+			Location:    safe(job.Location),
+			Salary:      safe(job.Salary),
+			Status:      safe(job.Status),
+			Details:     reportDetails(job),
+			Description: safe(job.Description),
+		}
+		if row.Title == "" {
+			row.Title = "(untitled)"
+		}
+		if job.SalaryAnnual > 0 {
+			row.SalarySort = strconv.FormatFloat(job.SalaryAnnual, 'f', 0, 64)
+		}
+		if !job.PostedAt.IsZero() {
+			row.Posted = job.PostedAt.Format("2006-01-02")
+			row.PostedSort = job.PostedAt.UTC().Format(time.RFC3339)
+		} else {
+			row.Posted = safe(job.PostedAtRaw)
+		}
+		if opts.IsNew != nil && opts.IsNew(job) {
+			row.New = true
+			data.New++
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	for site, count := range siteTotals {
+		data.Sites = append(data.Sites, reportSite{Name: site, Class: siteClass(site), Count: count})
+	}
+	sort.Slice(data.Sites, func(i, j int) bool { return data.Sites[i].Name < data.Sites[j].Name })
+
+	return reportTemplate.Execute(w, data)
+}
+
+// reportDetails joins the short job attributes shown under the title.
+func reportDetails(job models.Job) string {
+	var parts []string
+	if job.WorkArrangement != "" && job.WorkArrangement != models.WorkArrangementUnknown {
+		parts = append(parts, job.WorkArrangement)
+	} else if job.Remote {
+		parts = append(parts, models.WorkArrangementRemote)
+	}
+	for _, value := range []string{job.EmploymentType, job.Seniority} {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	if job.Repost {
+		parts = append(parts, "repost")
+	}
+	if job.Agency {
+		parts = append(parts, "agency")
+	}
+	if job.StatusNote != "" {
+		parts = append(parts, "note: "+safe(job.StatusNote))
+	}
+	if len(job.Sources) > 1 {
+		sites := make([]string, 0, len(job.Sources))
+		for _, source := range job.Sources {
+			sites = append(sites, safe(source.Site))
+		}
+		parts = append(parts, "sources: "+strings.Join(sites, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// siteClass keeps a site name usable as a CSS class.
+func siteClass(site string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+		return -1
+	}, site)
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestWriteHTML(t *testing.T) {
+	jobs := []models.Job{
+		{Site: "linkedin", Title: "SRE <script>alert(1)</script>", Company: "Acme", URL: "https://example.com/1", Description: "Line one\nLine two"},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", URL: "javascript:alert(1)"},
+	}
+	isNew := func(job models.Job) bool { return job.Site == "linkedin" }
+
+	var buf bytes.Buffer
+	if err := WriteJobs(&buf, jobs, FormatHTML, WriteOptions{IsNew: isNew}); err != nil {
+		t.Fatalf("WriteJobs() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<strong>2</strong> jobs",
+		"<strong>1</strong> new",
+		`<tr class="new" data-site="linkedin" data-new="true">`,
+		`<tr class="seen" data-site="indeed" data-new="false">`,
+		`<span class="badge indeed">indeed</span> 1`,
+		"SRE &lt;script&gt;alert(1)&lt;/script&gt;",
+		`<div class="description">Line one`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("report missing %q", want)
+		}
+	}
+	if strings.Contains(out, `href="javascript:`) {
+		t.Fatalf("report links to a javascript: URL")
+	}
+	if strings.Contains(out, "<link") || strings.Contains(out, "src=") {
+		t.Fatalf("report references external assets")
+	}
+}
+
+func TestWriteHTMLEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJobs(&buf, nil, FormatHTML, WriteOptions{}); err != nil {
+		t.Fatalf("WriteJobs() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "No results.") || strings.Contains(buf.String(), "only-new") {
+		t.Fatalf("unexpected empty report:\n%s", buf.String())
+	}
+}
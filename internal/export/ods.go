@@ -0,0 +1,143 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsNamespaces = `xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"` +
+	` xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"` +
+	` xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"` +
+	` xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"` +
+	` xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0"` +
+	` xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"` +
+	` xmlns:xlink="http://www.w3.org/1999/xlink"` +
+	` xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0"`
+
+const odsCellStyles = `<number:date-style style:name="N1">` +
+	`<number:year number:style="long"/><number:text>-</number:text>` +
+	`<number:month number:style="long"/><number:text>-</number:text>` +
+	`<number:day number:style="long"/><number:text> </number:text>` +
+	`<number:hours number:style="long"/><number:text>:</number:text>` +
+	`<number:minutes number:style="long"/>` +
+	`</number:date-style>` +
+	`<number:boolean-style style:name="N2"><number:boolean/></number:boolean-style>` +
+	`<style:style style:name="header" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>` +
+	`<style:style style:name="date" style:family="table-cell" style:data-style-name="N1"/>` +
+	`<style:style style:name="bool" style:family="table-cell" style:data-style-name="N2"/>`
+
+// odsCharWidth approximates the width of one character of the default font.
+const odsCharWidth = 0.2 // cm
+
+// writeODS writes an OpenDocument spreadsheet with the same sheets as
+// writeXLSX.
+func writeODS(w io.Writer, jobs []models.Job, opts WriteOptions) error {
+	sheets := spreadsheetSheets(jobs, opts)
+
+	var styles, tables, ranges, views strings.Builder
+	widthStyles := map[float64]string{}
+	for i, sheet := range sheets {
+		for _, width := range sheet.widths {
+			if _, ok := widthStyles[width]; ok {
+				continue
+			}
+			name := "co" + strconv.Itoa(len(widthStyles)+1)
+			widthStyles[width] = name
+			fmt.Fprintf(&styles, `<style:style style:name="%s" style:family="table-column"><style:table-column-properties style:column-width="%scm"/></style:style>`,
+				name, strconv.FormatFloat(width*odsCharWidth, 'f', 2, 64))
+		}
+		odsTable(&tables, sheet, widthStyles)
+		if sheet.filter {
+			fmt.Fprintf(&ranges, `<table:database-range table:name="__Anonymous_Sheet_DB__%d" table:target-range-address="%s" table:display-filter-buttons="true"/>`,
+				i, odsRange(sheet))
+		}
+		// Freeze the header row: a fixed split below row 1.
+		fmt.Fprintf(&views, `<config:config-item-map-entry config:name="%s">`+
+			`<config:config-item config:name="VerticalSplitMode" config:type="short">2</config:config-item>`+
+			`<config:config-item config:name="VerticalSplitPosition" config:type="int">1</config:config-item>`+
+			`<config:config-item config:name="ActiveSplitRange" config:type="short">2</config:config-item>`+
+			`<config:config-item config:name="PositionTop" config:type="int">0</config:config-item>`+
+			`<config:config-item config:name="PositionBottom" config:type="int">1</config:config-item>`+
+			`</config:config-item-map-entry>`, xmlText(sheet.name))
+	}
+	if ranges.Len() > 0 {
+		tables.WriteString(`<table:database-ranges>` + ranges.String() + `</table:database-ranges>`)
+	}
+
+	return writeZip(w, []zipPart{
+		{"mimetype", odsMimeType},
+		{"META-INF/manifest.xml", `<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+			`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>` +
+			`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+			`<manifest:file-entry manifest:full-path="settings.xml" manifest:media-type="text/xml"/>` +
+			`</manifest:manifest>`},
+		{"content.xml", `<office:document-content ` + odsNamespaces + ` office:version="1.2">` +
+			`<office:automatic-styles>` + odsCellStyles + styles.String() + `</office:automatic-styles>` +
+			`<office:body><office:spreadsheet>` + tables.String() + `</office:spreadsheet></office:body>` +
+			`</office:document-content>`},
+		{"settings.xml", `<office:document-settings ` + odsNamespaces + ` office:version="1.2"><office:settings>` +
+			`<config:config-item-set config:name="ooo:view-settings"><config:config-item-map-indexed config:name="Views">` +
+			`<config:config-item-map-entry><config:config-item config:name="ViewId" config:type="string">view1</config:config-item>` +
+			`<config:config-item-map-named config:name="Tables">` + views.String() + `</config:config-item-map-named>` +
+			`</config:config-item-map-entry></config:config-item-map-indexed></config:config-item-set>` +
+			`</office:settings></office:document-settings>`},
+	})
+}
+
+func odsTable(b *strings.Builder, sheet worksheet, widthStyles map[float64]string) {
+	fmt.Fprintf(b, `<table:table table:name="%s">`, xmlText(sheet.name))
+	for _, width := range sheet.widths {
+		fmt.Fprintf(b, `<table:table-column table:style-name="%s"/>`, widthStyles[width])
+	}
+	b.WriteString(`<table:table-header-rows><table:table-row>`)
+	for _, name := range sheet.header {
+		fmt.Fprintf(b, `<table:table-cell table:style-name="header" office:value-type="string"><text:p>%s</text:p></table:table-cell>`, xmlText(name))
+	}
+	b.WriteString(`</table:table-row></table:table-header-rows>`)
+	for _, row := range sheet.rows {
+		b.WriteString(`<table:table-row>`)
+		for _, cell := range row {
+			odsCell(b, cell)
+		}
+		b.WriteString(`</table:table-row>`)
+	}
+	b.WriteString(`</table:table>`)
+}
+
+func odsCell(b *strings.Builder, cell sheetCell) {
+	switch cell.kind {
+	case cellText:
+		b.WriteString(`<table:table-cell office:value-type="string">`)
+		for _, line := range strings.Split(cell.text, "\n") {
+			fmt.Fprintf(b, `<text:p>%s</text:p>`, xmlText(line))
+		}
+		b.WriteString(`</table:table-cell>`)
+	case cellLink:
+		text := xmlText(cell.text)
+		fmt.Fprintf(b, `<table:table-cell office:value-type="string"><text:p><text:a xlink:type="simple" xlink:href="%s">%s</text:a></text:p></table:table-cell>`, text, text)
+	case cellNumber:
+		value := strconv.FormatFloat(cell.number, 'f', -1, 64)
+		fmt.Fprintf(b, `<table:table-cell office:value-type="float" office:value="%s"><text:p>%s</text:p></table:table-cell>`, value, value)
+	case cellBool:
+		fmt.Fprintf(b, `<table:table-cell table:style-name="bool" office:value-type="boolean" office:boolean-value="%t"><text:p>%s</text:p></table:table-cell>`,
+			cell.flag, strings.ToUpper(strconv.FormatBool(cell.flag)))
+	case cellDate:
+		fmt.Fprintf(b, `<table:table-cell table:style-name="date" office:value-type="date" office:date-value="%s"><text:p>%s</text:p></table:table-cell>`,
+			cell.date.Format("2006-01-02T15:04:05"), cell.date.Format("2006-01-02 15:04"))
+	default:
+		b.WriteString(`<table:table-cell/>`)
+	}
+}
+
+// odsRange is the cell range covering the header and all rows, in ODF
+// notation.
+func odsRange(sheet worksheet) string {
+	name := xmlText("'" + strings.ReplaceAll(sheet.name, "'", "''") + "'")
+	return fmt.Sprintf("%s.A1:%s.%s%d", name, name, columnName(len(sheet.header)-1), len(sheet.rows)+1)
+}
@@ -0,0 +1,299 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// maxCellText is the longest string a spreadsheet cell holds in Excel.
+const maxCellText = 32767
+
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellText
+	cellNumber
+	cellBool
+	cellDate
+	cellLink
+)
+
+type sheetCell struct {
+	kind   cellKind
+	text   string
+	number float64
+	flag   bool
+	date   time.Time
+}
+
+// worksheet is a format-neutral sheet shared by the XLSX and ODS writers.
+type worksheet struct {
+	name   string
+	header []string
+	widths []float64 // column widths in characters
+	rows   [][]sheetCell
+	filter bool // autofilter on the header row
+}
+
+type spreadsheetColumn struct {
+	name  string
+	width float64
+	value func(models.Job) sheetCell
+}
+
+var spreadsheetColumns = []spreadsheetColumn{
+	{"site", 12, func(j models.Job) sheetCell { return textCell(j.Site) }},
+	{"id", 14, func(j models.Job) sheetCell { return textCell(j.ID) }},
+	{"title", 40, func(j models.Job) sheetCell { return textCell(j.Title) }},
+	{"company", 28, func(j models.Job) sheetCell { return textCell(j.Company) }},
+	{"location", 28, func(j models.Job) sheetCell { return textCell(j.Location) }},
+	{"city", 16, func(j models.Job) sheetCell { return textCell(j.City) }},
+	{"region", 16, func(j models.Job) sheetCell { return textCell(j.Region) }},
+	{"country_code", 8, func(j models.Job) sheetCell { return textCell(j.CountryCode) }},
+	{"url", 50, func(j models.Job) sheetCell { return linkCell(j.URL) }},
+	{"remote", 8, func(j models.Job) sheetCell { return sheetCell{kind: cellBool, flag: j.Remote} }},
+	{"work_arrangement", 12, func(j models.Job) sheetCell { return textCell(j.WorkArrangement) }},
+	{"employment_type", 12, func(j models.Job) sheetCell { return textCell(j.EmploymentType) }},
+	{"seniority", 10, func(j models.Job) sheetCell { return textCell(j.Seniority) }},
+	{"salary", 24, func(j models.Job) sheetCell { return textCell(j.Salary) }},
+	{"salary_min", 12, func(j models.Job) sheetCell { return numberCell(j.SalaryMin) }},
+	{"salary_max", 12, func(j models.Job) sheetCell { return numberCell(j.SalaryMax) }},
+	{"salary_currency", 8, func(j models.Job) sheetCell { return textCell(j.SalaryCurrency) }},
+	{"salary_period", 10, func(j models.Job) sheetCell { return textCell(j.SalaryPeriod) }},
+	{"salary_annual", 12, func(j models.Job) sheetCell { return numberCell(j.SalaryAnnual) }},
+	{"posted_at", 18, func(j models.Job) sheetCell { return dateCell(j.PostedAt) }},
+	{"first_seen", 18, func(j models.Job) sheetCell { return dateCell(j.FirstSeen) }},
+	{"repost", 8, func(j models.Job) sheetCell { return sheetCell{kind: cellBool, flag: j.Repost} }},
+	{"agency", 8, func(j models.Job) sheetCell { return sheetCell{kind: cellBool, flag: j.Agency} }},
+	{"status", 12, func(j models.Job) sheetCell { return textCell(j.Status) }},
+	{"status_note", 24, func(j models.Job) sheetCell { return textCell(j.StatusNote) }},
+	{"queries", 24, func(j models.Job) sheetCell { return textCell(strings.Join(j.Queries, ", ")) }},
+	{"snippet", 50, func(j models.Job) sheetCell { return textCell(j.Snippet) }},
+	{"description", 60, func(j models.Job) sheetCell { return textCell(j.Description) }},
+}
+
+// spreadsheetSheets returns the Jobs sheet with one typed row per job, a
+// Summary sheet with per-site counts and, when jobs record their queries, a
+// Queries sheet with per-query counts.
+func spreadsheetSheets(jobs []models.Job, opts WriteOptions) []worksheet {
+	jobsSheet := worksheet{name: "Jobs", rows: make([][]sheetCell, 0, len(jobs)), filter: true}
+	for _, column := range spreadsheetColumns {
+		jobsSheet.header = append(jobsSheet.header, column.name)
+		jobsSheet.widths = append(jobsSheet.widths, column.width)
+	}
+	if opts.IsNew != nil {
+		jobsSheet.header = append(jobsSheet.header, "new")
+		jobsSheet.widths = append(jobsSheet.widths, 8)
+	}
+	for _, job := range jobs {
+		row := make([]sheetCell, 0, len(jobsSheet.header))
+		for _, column := range spreadsheetColumns {
+			row = append(row, column.value(job))
+		}
+		if opts.IsNew != nil {
+			row = append(row, sheetCell{kind: cellBool, flag: opts.IsNew(job)})
+		}
+		jobsSheet.rows = append(jobsSheet.rows, row)
+	}
+	sheets := []worksheet{jobsSheet, summarySheet(jobs, opts)}
+	if queries, ok := querySheet(jobs, opts); ok {
+		sheets = append(sheets, queries)
+	}
+	return sheets
+}
+
+// querySheet counts jobs per query, in order of first appearance. A job
+// returned by several queries counts for each of them.
+func querySheet(jobs []models.Job, opts WriteOptions) (worksheet, bool) {
+	sheet := worksheet{
+		name:   "Queries",
+		header: []string{"query", "jobs"},
+		widths: []float64{32, 10},
+	}
+	if opts.IsNew != nil {
+		sheet.header = append(sheet.header, "new")
+		sheet.widths = append(sheet.widths, 10)
+	}
+	type queryCount struct{ jobs, newJobs int }
+	var order []string
+	counts := map[string]*queryCount{}
+	for _, job := range jobs {
+		isNew := opts.IsNew != nil && opts.IsNew(job)
+		for _, query := range job.Queries {
+			count := counts[query]
+			if count == nil {
+				count = &queryCount{}
+				counts[query] = count
+				order = append(order, query)
+			}
+			count.jobs++
+			if isNew {
+				count.newJobs++
+			}
+		}
+	}
+	if len(order) == 0 {
+		return sheet, false
+	}
+	for _, query := range order {
+		row := []sheetCell{textCell(query), countCell(counts[query].jobs)}
+		if opts.IsNew != nil {
+			row = append(row, countCell(counts[query].newJobs))
+		}
+		sheet.rows = append(sheet.rows, row)
+	}
+	return sheet, true
+}
+
+func summarySheet(jobs []models.Job, opts WriteOptions) worksheet {
+	type siteCount struct{ jobs, remote, newJobs int }
+	counts := map[string]*siteCount{}
+	total := &siteCount{}
+	for _, job := range jobs {
+		site := strings.ToLower(safe(job.Site))
+		if site == "" {
+			site = "unknown"
+		}
+		count := counts[site]
+		if count == nil {
+			count = &siteCount{}
+			counts[site] = count
+		}
+		for _, c := range []*siteCount{count, total} {
+			c.jobs++
+			if job.Remote {
+				c.remote++
+			}
+			if opts.IsNew != nil && opts.IsNew(job) {
+				c.newJobs++
+			}
+		}
+	}
+	sites := make([]string, 0, len(counts))
+	for site := range counts {
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+
+	sheet := worksheet{
+		name:   "Summary",
+		header: []string{"site", "jobs", "remote"},
+		widths: []float64{16, 10, 10},
+	}
+	if opts.IsNew != nil {
+		sheet.header = append(sheet.header, "new")
+		sheet.widths = append(sheet.widths, 10)
+	}
+	row := func(name string, count *siteCount) []sheetCell {
+		cells := []sheetCell{textCell(name), countCell(count.jobs), countCell(count.remote)}
+		if opts.IsNew != nil {
+			cells = append(cells, countCell(count.newJobs))
+		}
+		return cells
+	}
+	for _, site := range sites {
+		sheet.rows = append(sheet.rows, row(site, counts[site]))
+	}
+	sheet.rows = append(sheet.rows, row("total", total))
+	return sheet
+}
+
+func textCell(value string) sheetCell {
+	value = safe(value)
+	if value == "" {
+		return sheetCell{}
+	}
+	return sheetCell{kind: cellText, text: truncateCell(value)}
+}
+
+// linkCell hyperlinks absolute http(s) URLs; anything else stays text, since
+// spreadsheet apps reject files with malformed link targets.
+func linkCell(value string) sheetCell {
+	value = safe(value)
+	if value == "" {
+		return sheetCell{}
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || len(value) > 2000 {
+		return textCell(value)
+	}
+	return sheetCell{kind: cellLink, text: value}
+}
+
+func numberCell(value float64) sheetCell {
+	if value == 0 {
+		return sheetCell{}
+	}
+	return sheetCell{kind: cellNumber, number: value}
+}
+
+func countCell(value int) sheetCell {
+	return sheetCell{kind: cellNumber, number: float64(value)}
+}
+
+func dateCell(value time.Time) sheetCell {
+	if value.IsZero() {
+		return sheetCell{}
+	}
+	return sheetCell{kind: cellDate, date: value.UTC()}
+}
+
+func truncateCell(value string) string {
+	if len(value) <= maxCellText {
+		return value
+	}
+	value = value[:maxCellText]
+	for !utf8.ValidString(value) {
+		value = value[:len(value)-1]
+	}
+	return value
+}
+
+// columnName returns the spreadsheet column letters for a zero-based index.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// zipPart is one file of a zipped document package.
+type zipPart struct {
+	name string
+	body string
+}
+
+// writeZip writes parts in order, prefixing XML parts with the XML
+// declaration. An ODF "mimetype" part is stored uncompressed, as the
+// OpenDocument package format requires.
+func writeZip(w io.Writer, parts []zipPart) error {
+	zw := zip.NewWriter(w)
+	for _, part := range parts {
+		body := part.body
+		header := &zip.FileHeader{Name: part.name, Method: zip.Deflate}
+		if part.name == "mimetype" {
+			header.Method = zip.Store
+		} else {
+			body = xml.Header + body
+		}
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
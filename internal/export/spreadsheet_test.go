@@ -0,0 +1,144 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func spreadsheetJobs() []models.Job {
+	return []models.Job{
+		{Site: "stepstone", Title: "Entwickler (m/w/d) für Größenordnung", Company: "Müller & Söhne", URL: "https://www.stepstone.de/job--42.html",
+			Remote: true, SalaryMin: 60000, Queries: []string{"entwickler", "developer"}, PostedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", URL: "javascript:alert(1)", Description: "Line one\nLine two", Queries: []string{"developer"}},
+	}
+}
+
+// readZip returns the parts of a zip archive, checking every XML part parses.
+func readZip(t *testing.T, data []byte) (*zip.Reader, map[string]string) {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+	parts := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		parts[file.Name] = string(body)
+		if file.Name == "mimetype" {
+			continue
+		}
+		dec := xml.NewDecoder(bytes.NewReader(body))
+		for {
+			if _, err := dec.Token(); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				t.Fatalf("%s is not well-formed XML: %v", file.Name, err)
+			}
+		}
+	}
+	return reader, parts
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	isNew := func(job models.Job) bool { return job.Site == "indeed" }
+	if err := WriteJobs(&buf, spreadsheetJobs(), FormatXLSX, WriteOptions{IsNew: isNew}); err != nil {
+		t.Fatalf("WriteJobs() error = %v", err)
+	}
+	_, parts := readZip(t, buf.Bytes())
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("missing part %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`,
+		`<autoFilter ref="A1:AC3"/>`,
+		`<t xml:space="preserve">Müller &amp; Söhne</t>`,
+		`<c r="I2" t="inlineStr" s="3"><is><t>https://www.stepstone.de/job--42.html</t></is></c>`,
+		`<hyperlink ref="I2" r:id="rId1"/>`,
+		`<c r="J2" t="b"><v>1</v></c>`,
+		`<c r="O2"><v>60000</v></c>`,
+		`<c r="T2" s="2"><v>46296.5</v></c>`,
+		`<c r="AC3" t="b"><v>1</v></c>`,
+		`Line one&#xA;Line two`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Fatalf("sheet1 missing %q", want)
+		}
+	}
+	if strings.Contains(parts["xl/worksheets/_rels/sheet1.xml.rels"], "javascript:") {
+		t.Fatalf("sheet links to a javascript: URL")
+	}
+	queries := parts["xl/worksheets/sheet3.xml"]
+	for _, want := range []string{
+		`<t xml:space="preserve">entwickler</t></is></c><c r="B2"><v>1</v></c><c r="C2"><v>0</v></c>`,
+		`<t xml:space="preserve">developer</t></is></c><c r="B3"><v>2</v></c><c r="C3"><v>1</v></c>`,
+	} {
+		if !strings.Contains(queries, want) {
+			t.Fatalf("queries sheet missing %q:\n%s", want, queries)
+		}
+	}
+	summary := parts["xl/worksheets/sheet2.xml"]
+	if !strings.Contains(summary, `<t xml:space="preserve">stepstone</t>`) || !strings.Contains(summary, `<t xml:space="preserve">total</t>`) {
+		t.Fatalf("summary sheet missing site rows:\n%s", summary)
+	}
+}
+
+func TestWriteODS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJobs(&buf, spreadsheetJobs(), FormatODS, WriteOptions{}); err != nil {
+		t.Fatalf("WriteJobs() error = %v", err)
+	}
+	reader, parts := readZip(t, buf.Bytes())
+
+	first := reader.File[0]
+	if first.Name != "mimetype" || first.Method != zip.Store || parts["mimetype"] != odsMimeType {
+		t.Fatalf("first entry = %s (method %d), want stored mimetype", first.Name, first.Method)
+	}
+	content := parts["content.xml"]
+	for _, want := range []string{
+		`<table:table table:name="Jobs">`,
+		`<table:table table:name="Summary">`,
+		`<text:a xlink:type="simple" xlink:href="https://www.stepstone.de/job--42.html">`,
+		`office:value-type="boolean" office:boolean-value="true"`,
+		`office:value-type="date" office:date-value="2026-10-01T12:00:00"`,
+		`office:value-type="float" office:value="60000"`,
+		`<text:p>Line one</text:p><text:p>Line two</text:p>`,
+		`table:target-range-address="&#39;Jobs&#39;.A1:&#39;Jobs&#39;.AB3"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("content.xml missing %q", want)
+		}
+	}
+	if !strings.Contains(parts["settings.xml"], `config:name="VerticalSplitPosition" config:type="int">1<`) {
+		t.Fatalf("settings.xml does not freeze the header row")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(index); got != want {
+			t.Fatalf("columnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
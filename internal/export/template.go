@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// templateFuncs are available to --template files in addition to the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"add":   func(a, b int) int { return a + b },
+	// truncate shortens s to at most n characters, ending with "...".
+	"truncate": func(n int, s string) string {
+		if n <= 3 || utf8.RuneCountInString(s) <= n {
+			return s
+		}
+		return string([]rune(s)[:n-3]) + "..."
+	},
+	// date formats t with a Go layout; zero times become "".
+	"date": func(layout string, t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	},
+	// default returns value unless it is empty.
+	"default": func(fallback string, value string) string {
+		if strings.TrimSpace(value) == "" {
+			return fallback
+		}
+		return value
+	},
+	// slack escapes the characters Slack mrkdwn treats as control sequences.
+	"slack": func(s string) string {
+		return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+	},
+	"json": func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+}
+
+// ParseTemplate reads a text/template file that renders a []models.Job.
+func ParseTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(filepath.Base(path)).Funcs(templateFuncs).Option("missingkey=error").Parse(string(data))
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestWriteJobsTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slack.tmpl")
+	source := `{{range $i, $job := .}}{{add $i 1}}. <{{.URL}}|{{slack .Title}}> at {{upper .Site}}, {{date "2006-01-02" .PostedAt | default "n/a"}}, {{truncate 8 .Snippet}}
+{{end}}`
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	tmpl, err := ParseTemplate(path)
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+
+	jobs := []models.Job{
+		{Site: "linkedin", Title: "R&D <Lead>", URL: "https://example.com/1", PostedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Snippet: "Build great things"},
+		{Site: "indeed", Title: "SRE", URL: "https://example.com/2"},
+	}
+	var buf bytes.Buffer
+	if err := WriteJobs(&buf, jobs, FormatCSV, WriteOptions{Template: tmpl}); err != nil {
+		t.Fatalf("WriteJobs() error = %v", err)
+	}
+	want := "1. <https://example.com/1|R&amp;D &lt;Lead&gt;> at LINKEDIN, 2026-10-01, Build...\n" +
+		"2. <https://example.com/2|SRE> at INDEED, n/a, \n"
+	if buf.String() != want {
+		t.Fatalf("template output = %q, want %q", buf.String(), want)
+	}
+}
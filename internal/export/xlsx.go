@@ -0,0 +1,180 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+const (
+	xlsxMainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNS  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPkgNS  = "http://schemas.openxmlformats.org/package/2006/relationships"
+)
+
+// Cell style indexes into the cellXfs of xlsxStyles.
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleHeader
+	xlsxStyleDate
+	xlsxStyleLink
+)
+
+const xlsxStyles = `<styleSheet xmlns="` + xlsxMainNS + `">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm"/></numFmts>` +
+	`<fonts count="3">` +
+	`<font><sz val="11"/><name val="Calibri"/></font>` +
+	`<font><b/><sz val="11"/><name val="Calibri"/></font>` +
+	`<font><u/><sz val="11"/><color rgb="FF0563C1"/><name val="Calibri"/></font>` +
+	`</fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// excelEpoch is day zero of Excel's 1900 date system.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// writeXLSX writes an Office Open XML workbook: a Jobs sheet with typed cells,
+// clickable URLs, a frozen header row and an autofilter, and a Summary sheet.
+func writeXLSX(w io.Writer, jobs []models.Job, opts WriteOptions) error {
+	sheets := spreadsheetSheets(jobs, opts)
+
+	var types, sheetList, sheetRels, names strings.Builder
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&sheetList, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlText(sheet.name), n, n)
+		fmt.Fprintf(&sheetRels, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, n, xlsxRelNS, n)
+		if sheet.filter {
+			fmt.Fprintf(&names, `<definedName name="_xlnm._FilterDatabase" localSheetId="%d" hidden="1">'%s'!%s</definedName>`,
+				i, xmlText(sheet.name), absoluteRange(sheet))
+		}
+	}
+	definedNames := ""
+	if names.Len() > 0 {
+		definedNames = "<definedNames>" + names.String() + "</definedNames>"
+	}
+
+	parts := []zipPart{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			types.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="` + xlsxPkgNS + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxRelNS + `/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `">` +
+			`<sheets>` + sheetList.String() + `</sheets>` + definedNames + `</workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="` + xlsxPkgNS + `">` + sheetRels.String() +
+			fmt.Sprintf(`<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, len(sheets)+1, xlsxRelNS) +
+			`</Relationships>`},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, sheet := range sheets {
+		body, links := xlsxSheet(sheet, i == 0)
+		parts = append(parts, zipPart{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), body})
+		if links != "" {
+			parts = append(parts, zipPart{fmt.Sprintf("xl/worksheets/_rels/sheet%d.xml.rels", i+1), links})
+		}
+	}
+	return writeZip(w, parts)
+}
+
+// xlsxSheet returns the worksheet part and, when it has hyperlinks, its
+// relationships part.
+func xlsxSheet(sheet worksheet, selected bool) (string, string) {
+	var b, links, rels strings.Builder
+	b.WriteString(`<worksheet xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `">`)
+	fmt.Fprintf(&b, `<dimension ref="%s"/>`, sheetRange(sheet))
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"`)
+	if selected {
+		b.WriteString(` tabSelected="1"`)
+	}
+	b.WriteString(`><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/><selection pane="bottomLeft"/></sheetView></sheetViews>`)
+	b.WriteString(`<cols>`)
+	for i, width := range sheet.widths {
+		fmt.Fprintf(&b, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, strconv.FormatFloat(width, 'f', -1, 64))
+	}
+	b.WriteString(`</cols><sheetData>`)
+
+	b.WriteString(`<row r="1">`)
+	for i, name := range sheet.header {
+		fmt.Fprintf(&b, `<c r="%s1" t="inlineStr" s="%d"><is><t>%s</t></is></c>`, columnName(i), xlsxStyleHeader, xmlText(name))
+	}
+	b.WriteString(`</row>`)
+	linkCount := 0
+	for r, row := range sheet.rows {
+		n := r + 2
+		fmt.Fprintf(&b, `<row r="%d">`, n)
+		for i, cell := range row {
+			ref := columnName(i) + strconv.Itoa(n)
+			switch cell.kind {
+			case cellText:
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlText(cell.text))
+			case cellLink:
+				linkCount++
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr" s="%d"><is><t>%s</t></is></c>`, ref, xlsxStyleLink, xmlText(cell.text))
+				fmt.Fprintf(&links, `<hyperlink ref="%s" r:id="rId%d"/>`, ref, linkCount)
+				fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="%s/hyperlink" Target="%s" TargetMode="External"/>`,
+					linkCount, xlsxRelNS, xmlText(cell.text))
+			case cellNumber:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(cell.number, 'f', -1, 64))
+			case cellBool:
+				value := 0
+				if cell.flag {
+					value = 1
+				}
+				fmt.Fprintf(&b, `<c r="%s" t="b"><v>%d</v></c>`, ref, value)
+			case cellDate:
+				serial := cell.date.Sub(excelEpoch).Hours() / 24
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleDate, strconv.FormatFloat(serial, 'f', -1, 64))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	if sheet.filter {
+		fmt.Fprintf(&b, `<autoFilter ref="%s"/>`, sheetRange(sheet))
+	}
+	if links.Len() > 0 {
+		b.WriteString(`<hyperlinks>` + links.String() + `</hyperlinks>`)
+	}
+	b.WriteString(`</worksheet>`)
+
+	if rels.Len() == 0 {
+		return b.String(), ""
+	}
+	return b.String(), `<Relationships xmlns="` + xlsxPkgNS + `">` + rels.String() + `</Relationships>`
+}
+
+// sheetRange is the A1-style range covering the header and all rows.
+func sheetRange(sheet worksheet) string {
+	return fmt.Sprintf("A1:%s%d", columnName(len(sheet.header)-1), len(sheet.rows)+1)
+}
+
+func absoluteRange(sheet worksheet) string {
+	return fmt.Sprintf("$A$1:$%s$%d", columnName(len(sheet.header)-1), len(sheet.rows)+1)
+}
+
+// xmlText escapes value for XML character data and attributes, replacing
+// characters XML cannot represent.
+func xmlText(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
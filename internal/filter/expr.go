@@ -0,0 +1,301 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// Fields that an expression term can be scoped to with "field:term".
+var exprFields = map[string]func(models.Job) string{
+	"title":       func(j models.Job) string { return j.Title },
+	"company":     func(j models.Job) string { return j.Company },
+	"location":    func(j models.Job) string { return j.Location },
+	"description": func(j models.Job) string { return j.Snippet + "\n" + j.Description },
+}
+
+// Expr is a parsed --include/--exclude expression: terms and quoted phrases
+// combined with AND, OR, NOT and parentheses. Adjacent terms are ANDed.
+type Expr struct {
+	source string
+	root   exprNode
+}
+
+type exprNode interface {
+	match(job models.Job) bool
+}
+
+type termNode struct {
+	field string // empty searches every field
+	text  string // normalized
+}
+
+type notNode struct{ operand exprNode }
+
+type andNode struct{ operands []exprNode }
+
+type orNode struct{ operands []exprNode }
+
+// ParseExpr parses an expression such as
+// `title:(engineer OR entwickler) NOT "working student" NOT company:acme`.
+// Terms match case-insensitively anywhere in the field text, so "werkstudent"
+// also matches "Werkstudentin".
+func ParseExpr(source string) (*Expr, error) {
+	tokens, err := lexExpr(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s", p.tokens[p.pos])
+	}
+	return &Expr{source: source, root: root}, nil
+}
+
+// Match reports whether job satisfies the expression.
+func (e *Expr) Match(job models.Job) bool {
+	return e.root.match(job)
+}
+
+func (e *Expr) String() string {
+	return e.source
+}
+
+func (n termNode) match(job models.Job) bool {
+	if n.field != "" {
+		return strings.Contains(normalizeExprText(exprFields[n.field](job)), n.text)
+	}
+	for _, value := range []string{job.Title, job.Company, job.Location, job.Snippet, job.Description} {
+		if strings.Contains(normalizeExprText(value), n.text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n notNode) match(job models.Job) bool {
+	return !n.operand.match(job)
+}
+
+func (n andNode) match(job models.Job) bool {
+	for _, operand := range n.operands {
+		if !operand.match(job) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n orNode) match(job models.Job) bool {
+	for _, operand := range n.operands {
+		if operand.match(job) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeExprText lowercases value and collapses whitespace so phrases
+// match across line breaks.
+func normalizeExprText(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
+
+type tokenKind int
+
+const (
+	tokenTerm tokenKind = iota
+	tokenField
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t exprToken) String() string {
+	switch t.kind {
+	case tokenOpen:
+		return fmt.Sprintf("'(' at position %d", t.pos)
+	case tokenClose:
+		return fmt.Sprintf("')' at position %d", t.pos)
+	case tokenField:
+		return fmt.Sprintf("%q at position %d", t.text+":", t.pos)
+	default:
+		return fmt.Sprintf("%q at position %d", t.text, t.pos)
+	}
+}
+
+func lexExpr(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '(':
+			tokens = append(tokens, exprToken{kind: tokenOpen, pos: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, exprToken{kind: tokenClose, pos: i + 1})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quote at position %d", i+1)
+			}
+			tokens = append(tokens, exprToken{kind: tokenTerm, text: string(runes[i+1 : end]), pos: i + 1})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r()\"", runes[i]) {
+				if runes[i] == ':' {
+					if _, ok := exprFields[strings.ToLower(string(runes[start:i]))]; ok {
+						break
+					}
+				}
+				i++
+			}
+			word := string(runes[start:i])
+			if i < len(runes) && runes[i] == ':' {
+				tokens = append(tokens, exprToken{kind: tokenField, text: strings.ToLower(word), pos: start + 1})
+				i++
+				continue
+			}
+			kind := tokenTerm
+			switch word {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: word, pos: start + 1})
+		}
+	}
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() (exprToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return exprToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *exprParser) parseOr(field string) (exprNode, error) {
+	left, err := p.parseAnd(field)
+	if err != nil {
+		return nil, err
+	}
+	operands := []exprNode{left}
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind != tokenOr {
+			break
+		}
+		p.pos++
+		right, err := p.parseAnd(field)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return orNode{operands: operands}, nil
+}
+
+func (p *exprParser) parseAnd(field string) (exprNode, error) {
+	left, err := p.parseUnary(field)
+	if err != nil {
+		return nil, err
+	}
+	operands := []exprNode{left}
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind == tokenOr || token.kind == tokenClose {
+			break
+		}
+		if token.kind == tokenAnd {
+			p.pos++
+		}
+		right, err := p.parseUnary(field)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return andNode{operands: operands}, nil
+}
+
+func (p *exprParser) parseUnary(field string) (exprNode, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	switch token.kind {
+	case tokenNot:
+		p.pos++
+		operand, err := p.parseUnary(field)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	case tokenField:
+		if field != "" {
+			return nil, fmt.Errorf("nested field %s", token)
+		}
+		p.pos++
+		return p.parseUnary(token.text)
+	case tokenOpen:
+		p.pos++
+		inner, err := p.parseOr(field)
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokenClose {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", token.pos)
+		}
+		p.pos++
+		return inner, nil
+	case tokenTerm:
+		p.pos++
+		text := normalizeExprText(token.text)
+		if text == "" {
+			return nil, fmt.Errorf("empty phrase at position %d", token.pos)
+		}
+		return termNode{field: field, text: text}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s", token)
+	}
+}
@@ -0,0 +1,69 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestParseExpr_Match(t *testing.T) {
+	job := models.Job{
+		Title:       "Werkstudentin Backend Engineering (m/w/d)",
+		Company:     "Acme Recruiting GmbH",
+		Location:    "Berlin",
+		Description: "Für unseren Kunden suchen wir\nab sofort Unterstützung.",
+	}
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{"werkstudent", true},
+		{"WERKSTUDENT", true},
+		{"sales", false},
+		{"werkstudent OR sales", true},
+		{"werkstudent sales", false},
+		{"werkstudent AND backend", true},
+		{"NOT sales", true},
+		{"NOT werkstudent", false},
+		{"title:acme", false},
+		{"company:acme", true},
+		{"location:berlin", true},
+		{`description:"unseren kunden suchen"`, true},
+		{`"kunden suchen wir ab sofort"`, true},
+		{"title:(sales OR praktikum)", false},
+		{"title:(sales OR backend) NOT company:google", true},
+		{"(sales OR praktikum) OR company:acme", true},
+		{"sales OR praktikum AND backend", false},
+		{"NOT (sales OR praktikum)", true},
+		{"c++", false},
+	}
+	for _, tc := range cases {
+		expr, err := ParseExpr(tc.expr)
+		if err != nil {
+			t.Fatalf("ParseExpr(%q) error = %v", tc.expr, err)
+		}
+		if got := expr.Match(job); got != tc.want {
+			t.Errorf("ParseExpr(%q).Match() = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestParseExpr_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"   ",
+		"(sales",
+		"sales)",
+		`"sales`,
+		`""`,
+		"sales OR",
+		"NOT",
+		"title:",
+		"title:company:acme",
+		"AND sales",
+	} {
+		if _, err := ParseExpr(expr); err == nil {
+			t.Errorf("ParseExpr(%q) error = nil, want error", expr)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package filter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/company"
+	"github.com/jimezsa/jobcli/internal/location"
+	"github.com/jimezsa/jobcli/internal/models"
+	"github.com/jimezsa/jobcli/internal/normalize"
+	"github.com/jimezsa/jobcli/internal/salary"
+)
+
+// Reason identifies why a job was dropped by post-scrape filtering.
+type Reason string
+
+const (
+	ReasonHours       Reason = "hours"
+	ReasonArrangement Reason = "arrangement"
+	ReasonJobType     Reason = "job_type"
+	ReasonSalary      Reason = "salary"
+	// ReasonSeniority covers both --seniority and --exclude-seniority.
+	ReasonSeniority Reason = "seniority"
+	ReasonRadius    Reason = "radius"
+	// ReasonCompany covers both --only-companies and --exclude-companies.
+	ReasonCompany Reason = "company"
+	ReasonAgency  Reason = "agency"
+	ReasonInclude Reason = "include"
+	ReasonExclude Reason = "exclude"
+)
+
+// Criteria are the requested constraints re-checked against scraped jobs.
+// Site-side filters are best-effort, so every scraper result passes through here.
+type Criteria struct {
+	Hours int
+	// Arrangements keeps only jobs with one of the listed work arrangements.
+	Arrangements []string
+	JobType      string
+	// MinSalary is an annual amount in MinSalaryCurrency, or EUR when
+	// MinSalaryCurrency is empty.
+	MinSalary         float64
+	MinSalaryCurrency string
+	// Seniority keeps only the listed levels; ExcludeSeniority drops them.
+	Seniority        []string
+	ExcludeSeniority []string
+	// RadiusKm keeps jobs within this distance of Origin.
+	Origin   location.Place
+	RadiusKm float64
+	// Include keeps only jobs matching the expression; Exclude drops them.
+	Include *Expr
+	Exclude *Expr
+	// OnlyCompanies keeps only jobs from the listed companies; ExcludeCompanies
+	// drops them.
+	OnlyCompanies    company.Set
+	ExcludeCompanies company.Set
+	// ExcludeAgencies drops jobs flagged as staffing agency postings.
+	ExcludeAgencies bool
+	// Strict drops jobs whose posting date, work arrangement, job type, salary or seniority is unknown instead of keeping them.
+	Strict bool
+	Now    time.Time
+}
+
+// Stats counts dropped jobs per reason.
+type Stats struct {
+	Total   int
+	Kept    int
+	Dropped map[Reason]int
+}
+
+// DroppedTotal returns the number of jobs removed by all reasons.
+func (s Stats) DroppedTotal() int {
+	total := 0
+	for _, count := range s.Dropped {
+		total += count
+	}
+	return total
+}
+
+// ReasonCount is a single reason with its dropped count.
+type ReasonCount struct {
+	Reason Reason
+	Count  int
+}
+
+// Reasons returns non-zero drop counts sorted by reason name.
+func (s Stats) Reasons() []ReasonCount {
+	counts := make([]ReasonCount, 0, len(s.Dropped))
+	for reason, count := range s.Dropped {
+		if count == 0 {
+			continue
+		}
+		counts = append(counts, ReasonCount{Reason: reason, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Reason < counts[j].Reason
+	})
+	return counts
+}
+
+// Apply returns jobs matching criteria. Each dropped job is counted once,
+// under the first failing check.
+func Apply(jobs []models.Job, criteria Criteria) ([]models.Job, Stats) {
+	stats := Stats{
+		Total:   len(jobs),
+		Dropped: map[Reason]int{},
+	}
+	if criteria.Now.IsZero() {
+		criteria.Now = time.Now()
+	}
+
+	out := make([]models.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if reason, drop := check(job, criteria); drop {
+			stats.Dropped[reason]++
+			continue
+		}
+		out = append(out, job)
+	}
+
+	stats.Kept = len(out)
+	return out, stats
+}
+
+// Check reports the reason job fails criteria, or false when it is kept.
+func Check(job models.Job, criteria Criteria) (Reason, bool) {
+	if criteria.Now.IsZero() {
+		criteria.Now = time.Now()
+	}
+	return check(job, criteria)
+}
+
+func check(job models.Job, criteria Criteria) (Reason, bool) {
+	if criteria.Hours > 0 && !matchesHours(job, criteria) {
+		return ReasonHours, true
+	}
+	if len(criteria.Arrangements) > 0 && !matchesArrangement(job, criteria) {
+		return ReasonArrangement, true
+	}
+	if criteria.JobType != "" && !matchesJobType(job, criteria) {
+		return ReasonJobType, true
+	}
+	if criteria.MinSalary > 0 && !matchesMinSalary(job, criteria) {
+		return ReasonSalary, true
+	}
+	if (len(criteria.Seniority) > 0 || len(criteria.ExcludeSeniority) > 0) && !matchesSeniority(job, criteria) {
+		return ReasonSeniority, true
+	}
+	if criteria.RadiusKm > 0 && !matchesRadius(job, criteria) {
+		return ReasonRadius, true
+	}
+	if len(criteria.OnlyCompanies) > 0 && !criteria.OnlyCompanies.Contains(job.Company) {
+		return ReasonCompany, true
+	}
+	if criteria.ExcludeCompanies.Contains(job.Company) {
+		return ReasonCompany, true
+	}
+	if criteria.ExcludeAgencies && job.Agency {
+		return ReasonAgency, true
+	}
+	if criteria.Include != nil && !criteria.Include.Match(job) {
+		return ReasonInclude, true
+	}
+	if criteria.Exclude != nil && criteria.Exclude.Match(job) {
+		return ReasonExclude, true
+	}
+	return "", false
+}
+
+func matchesHours(job models.Job, criteria Criteria) bool {
+	if job.PostedAt.IsZero() {
+		return !criteria.Strict
+	}
+
+	cutoff := criteria.Now.Add(-time.Duration(criteria.Hours) * time.Hour)
+	if !criteria.Strict && job.PostedAtPrecision == models.PostedAtDay {
+		// Day-precision dates ("yesterday", "2024-03-01") get one day of slack.
+		cutoff = cutoff.Add(-24 * time.Hour)
+	}
+	return !job.PostedAt.Before(cutoff)
+}
+
+// matchesArrangement keeps jobs with a listed work arrangement. Jobs whose
+// arrangement cannot be determined are kept unless strict.
+func matchesArrangement(job models.Job, criteria Criteria) bool {
+	arrangement := normalize.InferWorkArrangement(job)
+	if arrangement == models.WorkArrangementUnknown {
+		return !criteria.Strict
+	}
+	return containsString(criteria.Arrangements, arrangement)
+}
+
+func matchesJobType(job models.Job, criteria Criteria) bool {
+	want := normalize.ParseEmploymentType(criteria.JobType)
+	types := normalize.EmploymentTypes(job)
+	if len(types) == 0 {
+		return !criteria.Strict
+	}
+	for _, jobType := range types {
+		if jobType == want {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMinSalary keeps a job when the top of its annualized range reaches the minimum.
+func matchesMinSalary(job models.Job, criteria Criteria) bool {
+	details, ok := salary.FromJob(job)
+	if !ok {
+		return !criteria.Strict
+	}
+
+	min, max := details.Annual()
+	upper := max
+	if upper < min {
+		upper = min
+	}
+	currency := criteria.MinSalaryCurrency
+	if currency == "" {
+		currency = "EUR"
+	}
+	if details.Currency != "" {
+		converted, ok := salary.Convert(upper, details.Currency, currency)
+		if !ok {
+			return !criteria.Strict
+		}
+		upper = converted
+	}
+	return upper >= criteria.MinSalary
+}
+
+// matchesSeniority applies --seniority and --exclude-seniority. Jobs whose level
+// cannot be inferred only fail the include list, and only in strict mode.
+func matchesSeniority(job models.Job, criteria Criteria) bool {
+	level := job.Seniority
+	if level == "" {
+		level = normalize.InferSeniority(job)
+	}
+	if level == "" {
+		return len(criteria.Seniority) == 0 || !criteria.Strict
+	}
+	if containsString(criteria.ExcludeSeniority, level) {
+		return false
+	}
+	return len(criteria.Seniority) == 0 || containsString(criteria.Seniority, level)
+}
+
+// matchesRadius keeps jobs whose normalized location lies within the radius.
+// Jobs without coordinates (e.g. "Remote", unknown towns) are kept unless strict.
+func matchesRadius(job models.Job, criteria Criteria) bool {
+	place := location.FromJob(job)
+	if !place.HasCoordinates() {
+		return !criteria.Strict
+	}
+	return location.DistanceKm(criteria.Origin, place) <= criteria.RadiusKm
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/company"
+	"github.com/jimezsa/jobcli/internal/location"
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestApply(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{Title: "Fresh", PostedAt: now.Add(-2 * time.Hour), PostedAtPrecision: models.PostedAtExact, Remote: true, JobType: "FULL_TIME"},
+		{Title: "Old", PostedAt: now.Add(-72 * time.Hour), PostedAtPrecision: models.PostedAtExact, Remote: true},
+		{Title: "Yesterday", PostedAt: now.Add(-24 * time.Hour), PostedAtPrecision: models.PostedAtDay, Remote: true, JobType: "Vollzeit"},
+		{Title: "Undated", Remote: true},
+		{Title: "Onsite", PostedAt: now, Remote: false},
+		{Title: "Werkstudent Backend (m/w/d)", PostedAt: now, Remote: true},
+		{Title: "Part", PostedAt: now, Remote: true, JobType: "PART_TIME"},
+	}
+	criteria := Criteria{Hours: 12, Arrangements: []string{models.WorkArrangementRemote}, JobType: "fulltime", Now: now}
+
+	got, stats := Apply(jobs, criteria)
+	titles := jobTitles(got)
+	want := []string{"Fresh", "Yesterday", "Undated"}
+	if len(titles) != len(want) {
+		t.Fatalf("Apply() kept %v, want %v", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("Apply() kept %v, want %v", titles, want)
+		}
+	}
+	if stats.Dropped[ReasonHours] != 1 || stats.Dropped[ReasonArrangement] != 1 || stats.Dropped[ReasonJobType] != 2 {
+		t.Fatalf("unexpected drop counts: %+v", stats.Dropped)
+	}
+	if stats.DroppedTotal() != 4 || stats.Kept != 3 || stats.Total != 7 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	criteria.Strict = true
+	got, stats = Apply(jobs, criteria)
+	if titles := jobTitles(got); len(titles) != 1 || titles[0] != "Fresh" {
+		t.Fatalf("strict Apply() kept %v, want [Fresh]", titles)
+	}
+	if stats.Dropped[ReasonHours] != 3 {
+		t.Fatalf("strict hours drops = %d, want 3", stats.Dropped[ReasonHours])
+	}
+}
+
+func TestApply_NoCriteriaKeepsEverything(t *testing.T) {
+	jobs := []models.Job{{Title: "A"}, {Title: "B", Remote: true}}
+	got, stats := Apply(jobs, Criteria{})
+	if len(got) != 2 || stats.DroppedTotal() != 0 {
+		t.Fatalf("Apply() = %d jobs, stats %+v", len(got), stats)
+	}
+}
+
+func TestApply_MinSalaryConvertsCurrency(t *testing.T) {
+	jobs := []models.Job{
+		{Title: "USD", SalaryMin: 70000, SalaryMax: 80000, SalaryCurrency: "USD", SalaryPeriod: "year"},
+		{Title: "EUR low", SalaryMin: 40000, SalaryMax: 50000, SalaryCurrency: "EUR", SalaryPeriod: "year"},
+		{Title: "Hourly", SalaryMin: 40, SalaryCurrency: "EUR", SalaryPeriod: "hour"},
+		{Title: "Unknown"},
+	}
+
+	got, stats := Apply(jobs, Criteria{MinSalary: 60000, MinSalaryCurrency: "EUR"})
+	titles := jobTitles(got)
+	if len(titles) != 3 || titles[0] != "USD" || titles[1] != "Hourly" || titles[2] != "Unknown" {
+		t.Fatalf("Apply() kept %v", titles)
+	}
+	if stats.Dropped[ReasonSalary] != 1 {
+		t.Fatalf("salary drops = %d, want 1", stats.Dropped[ReasonSalary])
+	}
+
+	got, _ = Apply(jobs, Criteria{MinSalary: 60000, MinSalaryCurrency: "EUR", Strict: true})
+	if titles := jobTitles(got); len(titles) != 2 {
+		t.Fatalf("strict Apply() kept %v", titles)
+	}
+}
+
+func TestApply_MinSalaryDefaultsToEUR(t *testing.T) {
+	jobs := []models.Job{
+		{Title: "JPY", SalaryMin: 6000000, SalaryCurrency: "JPY", SalaryPeriod: "year"},
+		{Title: "GBP", SalaryMin: 45000, SalaryCurrency: "GBP", SalaryPeriod: "year"},
+		{Title: "EUR", SalaryMin: 55000, SalaryCurrency: "EUR", SalaryPeriod: "year"},
+	}
+
+	got, _ := Apply(jobs, Criteria{MinSalary: 50000})
+	titles := jobTitles(got)
+	if len(titles) != 2 || titles[0] != "GBP" || titles[1] != "EUR" {
+		t.Fatalf("Apply() kept %v, want GBP and EUR", titles)
+	}
+}
+
+func TestApply_Arrangement(t *testing.T) {
+	jobs := []models.Job{
+		{Title: "A", Location: "Munich (Hybrid)"},
+		{Title: "B", Location: "Remote"},
+		{Title: "C", Location: "Berlin", Snippet: "No remote"},
+		{Title: "D", Location: "Hamburg"},
+	}
+
+	got, stats := Apply(jobs, Criteria{Arrangements: []string{models.WorkArrangementRemote, models.WorkArrangementHybrid}})
+	if titles := jobTitles(got); len(titles) != 3 || titles[0] != "A" || titles[1] != "B" || titles[2] != "D" {
+		t.Fatalf("Apply() kept %v, want [A B D]", titles)
+	}
+	if stats.Dropped[ReasonArrangement] != 1 {
+		t.Fatalf("Dropped[arrangement] = %d, want 1", stats.Dropped[ReasonArrangement])
+	}
+
+	// Postings that don't state an arrangement are kept unless strict.
+	got, _ = Apply(jobs, Criteria{Arrangements: []string{models.WorkArrangementOnsite}})
+	if titles := jobTitles(got); len(titles) != 2 || titles[0] != "C" || titles[1] != "D" {
+		t.Fatalf("Apply() onsite kept %v, want [C D]", titles)
+	}
+	got, stats = Apply(jobs, Criteria{Arrangements: []string{models.WorkArrangementOnsite}, Strict: true})
+	if titles := jobTitles(got); len(titles) != 1 || titles[0] != "C" {
+		t.Fatalf("strict Apply() onsite kept %v, want [C]", titles)
+	}
+	if stats.Dropped[ReasonArrangement] != 3 {
+		t.Fatalf("strict Dropped[arrangement] = %d, want 3", stats.Dropped[ReasonArrangement])
+	}
+}
+
+func TestApply_Radius(t *testing.T) {
+	origin, _ := location.Parse("Munich", "")
+	jobs := []models.Job{
+		{Title: "Garching", Location: "Garching bei München"},
+		{Title: "Augsburg", Location: "Augsburg"},
+		{Title: "Berlin", Location: "Berlin"},
+		{Title: "Remote", Location: "Remote"},
+	}
+	for i := range jobs {
+		location.Apply(&jobs[i], "de")
+	}
+
+	got, stats := Apply(jobs, Criteria{Origin: origin, RadiusKm: 60})
+	if titles := jobTitles(got); len(titles) != 3 || titles[2] != "Remote" {
+		t.Fatalf("Apply() kept %v, want [Garching Augsburg Remote]", titles)
+	}
+	if stats.Dropped[ReasonRadius] != 1 {
+		t.Fatalf("Dropped[radius] = %d, want 1", stats.Dropped[ReasonRadius])
+	}
+
+	got, _ = Apply(jobs, Criteria{Origin: origin, RadiusKm: 30, Strict: true})
+	if titles := jobTitles(got); len(titles) != 1 || titles[0] != "Garching" {
+		t.Fatalf("strict Apply() kept %v, want [Garching]", titles)
+	}
+}
+
+func TestApply_Seniority(t *testing.T) {
+	jobs := []models.Job{
+		{Title: "Werkstudent Data Engineering"},
+		{Title: "Senior Backend Engineer"},
+		{Title: "Backend Engineer", Description: "You bring 3+ years of experience with Go."},
+		{Title: "Backend Engineer"},
+	}
+
+	got, stats := Apply(jobs, Criteria{ExcludeSeniority: []string{"intern"}})
+	if titles := jobTitles(got); len(titles) != 3 || titles[0] != "Senior Backend Engineer" {
+		t.Fatalf("Apply() exclude kept %v", titles)
+	}
+	if stats.Dropped[ReasonSeniority] != 1 {
+		t.Fatalf("Dropped[seniority] = %d, want 1", stats.Dropped[ReasonSeniority])
+	}
+
+	got, _ = Apply(jobs, Criteria{Seniority: []string{"mid", "senior"}})
+	if titles := jobTitles(got); len(titles) != 3 {
+		t.Fatalf("Apply() include kept %v", titles)
+	}
+
+	got, _ = Apply(jobs, Criteria{Seniority: []string{"mid", "senior"}, Strict: true})
+	if titles := jobTitles(got); len(titles) != 2 {
+		t.Fatalf("strict Apply() include kept %v", titles)
+	}
+}
+
+func TestApply_IncludeExclude(t *testing.T) {
+	jobs := []models.Job{
+		{Title: "Backend Engineer"},
+		{Title: "Werkstudent Backend"},
+		{Title: "Sales Manager"},
+		{Title: "Frontend Engineer"},
+	}
+	include, err := ParseExpr("engineer OR backend")
+	if err != nil {
+		t.Fatalf("ParseExpr() error = %v", err)
+	}
+	exclude, err := ParseExpr("title:(werkstudent OR praktikum)")
+	if err != nil {
+		t.Fatalf("ParseExpr() error = %v", err)
+	}
+
+	got, stats := Apply(jobs, Criteria{Include: include, Exclude: exclude})
+	if titles := jobTitles(got); len(titles) != 2 || titles[0] != "Backend Engineer" || titles[1] != "Frontend Engineer" {
+		t.Fatalf("Apply() kept %v", titles)
+	}
+	if stats.Dropped[ReasonInclude] != 1 || stats.Dropped[ReasonExclude] != 1 {
+		t.Fatalf("unexpected drop counts: %+v", stats.Dropped)
+	}
+}
+
+func TestApply_Companies(t *testing.T) {
+	list := company.NewList(map[string][]string{"Hays": {"Hays Professional Solutions GmbH"}})
+	jobs := []models.Job{
+		{Title: "A", Company: "Hays AG"},
+		{Title: "B", Company: "Hays Professional Solutions GmbH"},
+		{Title: "C", Company: "Acme GmbH"},
+		{Title: "D"},
+	}
+
+	got, stats := Apply(jobs, Criteria{ExcludeCompanies: list.Resolve([]string{"hays"})})
+	if titles := jobTitles(got); len(titles) != 2 || titles[0] != "C" || titles[1] != "D" {
+		t.Fatalf("Apply() exclude kept %v", titles)
+	}
+	if stats.Dropped[ReasonCompany] != 2 {
+		t.Fatalf("Dropped[company] = %d, want 2", stats.Dropped[ReasonCompany])
+	}
+
+	got, _ = Apply(jobs, Criteria{OnlyCompanies: list.Resolve([]string{"Acme"})})
+	if titles := jobTitles(got); len(titles) != 1 || titles[0] != "C" {
+		t.Fatalf("Apply() only kept %v", titles)
+	}
+}
+
+func TestApply_ExcludeAgencies(t *testing.T) {
+	jobs := []models.Job{{Title: "A", Agency: true}, {Title: "B"}}
+	got, stats := Apply(jobs, Criteria{ExcludeAgencies: true})
+	if titles := jobTitles(got); len(titles) != 1 || titles[0] != "B" {
+		t.Fatalf("Apply() kept %v", titles)
+	}
+	if stats.Dropped[ReasonAgency] != 1 {
+		t.Fatalf("Dropped[agency] = %d, want 1", stats.Dropped[ReasonAgency])
+	}
+}
+
+func jobTitles(jobs []models.Job) []string {
+	titles := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		titles = append(titles, job.Title)
+	}
+	return titles
+}
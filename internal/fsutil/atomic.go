@@ -0,0 +1,83 @@
+package fsutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// WriteFile writes data to a temporary file next to path and renames it over
+// path, so readers and crashes never observe a truncated file. An existing
+// file keeps its permissions; new files get perm.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	file, err := Create(path, perm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	return file.Commit()
+}
+
+// File is a temporary file that replaces its target path on Commit. Close
+// without Commit discards it and leaves the target untouched.
+type File struct {
+	*os.File
+	path      string
+	committed bool
+}
+
+// Create opens a temporary file in the directory of path for an atomic
+// replace of path.
+func Create(path string, perm os.FileMode) (*File, error) {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	if err := tmp.Chmod(perm); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return &File{File: tmp, path: path}, nil
+}
+
+// Commit flushes the temporary file to disk and renames it over the target.
+func (f *File) Commit() error {
+	if f.committed {
+		return nil
+	}
+	if err := f.File.Sync(); err != nil {
+		return err
+	}
+	if err := f.File.Close(); err != nil {
+		os.Remove(f.File.Name())
+		return err
+	}
+	if err := os.Rename(f.File.Name(), f.path); err != nil {
+		os.Remove(f.File.Name())
+		return err
+	}
+	f.committed = true
+	return nil
+}
+
+// Close discards the temporary file unless it was committed.
+func (f *File) Close() error {
+	if f.committed {
+		return nil
+	}
+	f.committed = true
+	err := f.File.Close()
+	os.Remove(f.File.Name())
+	return err
+}
@@ -0,0 +1,37 @@
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Backup copies path to "<path>.bak.1" before it is rewritten, shifting older
+// copies up to "<path>.bak.<keep>" and dropping the oldest. It does nothing
+// when keep is zero or path does not exist yet.
+func Backup(path string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(backupPath(path, keep)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for n := keep - 1; n >= 1; n-- {
+		if err := os.Rename(backupPath(path, n), backupPath(path, n+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return WriteFile(backupPath(path, 1), data, 0o644)
+}
+
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.bak.%d", path, n)
+}
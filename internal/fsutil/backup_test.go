@@ -0,0 +1,40 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs_seen.json")
+	if err := Backup(path, 2); err != nil {
+		t.Fatalf("Backup() of missing file error = %v", err)
+	}
+
+	for _, content := range []string{"v1", "v2", "v3"} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if err := Backup(path, 2); err != nil {
+			t.Fatalf("Backup() error = %v", err)
+		}
+	}
+
+	want := map[string]string{
+		path + ".bak.1": "v3",
+		path + ".bak.2": "v2",
+	}
+	for backup, content := range want {
+		data, err := os.ReadFile(backup)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", backup, err)
+		}
+		if string(data) != content {
+			t.Fatalf("%s = %q, want %q", backup, data, content)
+		}
+	}
+	if _, err := os.Stat(path + ".bak.3"); !os.IsNotExist(err) {
+		t.Fatalf("unexpected %s.bak.3, err = %v", path, err)
+	}
+}
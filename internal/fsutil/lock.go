@@ -0,0 +1,59 @@
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrLocked reports that another process held a lock past the timeout.
+var ErrLocked = errors.New("locked by another process")
+
+const lockPollInterval = 100 * time.Millisecond
+
+// Lock is an exclusive advisory lock held on a "<path>.lock" file.
+type Lock struct {
+	file *os.File
+}
+
+// LockPath takes an exclusive advisory lock guarding path, waiting up to
+// timeout for other holders to release it. The lock file is left in place so
+// concurrent processes always lock the same inode.
+func LockPath(path string, timeout time.Duration) (*Lock, error) {
+	lockPath := path + ".lock"
+	file, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if locked {
+			return &Lock{file: file}, nil
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("%s: %w", lockPath, ErrLocked)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// Unlock releases the lock.
+func (l *Lock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
@@ -0,0 +1,14 @@
+//go:build !unix && !windows
+
+package fsutil
+
+import "os"
+
+// Platforms without file locking run unlocked.
+func tryLock(file *os.File) (bool, error) {
+	return true, nil
+}
+
+func unlock(file *os.File) error {
+	return nil
+}
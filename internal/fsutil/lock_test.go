@@ -0,0 +1,29 @@
+package fsutil
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockPathExcludesSecondHolder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs_seen.json")
+	lock, err := LockPath(path, time.Second)
+	if err != nil {
+		t.Fatalf("LockPath() error = %v", err)
+	}
+
+	if _, err := LockPath(path, 0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("LockPath() while held error = %v, want ErrLocked", err)
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	again, err := LockPath(path, time.Second)
+	if err != nil {
+		t.Fatalf("LockPath() after unlock error = %v", err)
+	}
+	again.Unlock()
+}
@@ -0,0 +1,393 @@
+package location
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+//go:embed cities.csv
+var citiesCSV string
+
+// Place is a normalized location. Country is an ISO 3166-1 alpha-2 code;
+// Lat/Lon are only set when a city was recognized.
+type Place struct {
+	City    string
+	Region  string
+	Country string
+	Lat     float64
+	Lon     float64
+}
+
+// HasCoordinates reports whether the place can be used for distance checks.
+func (p Place) HasCoordinates() bool {
+	return p.Lat != 0 || p.Lon != 0
+}
+
+type gazetteer struct {
+	cities    []Place
+	cityIndex map[string][]int
+	// regions maps country code -> folded region name/alias -> canonical name.
+	regions   map[string]map[string]string
+	countries map[string]string
+	// stateCodes are folded country codes that are also US state codes
+	// ("ca", "de", "il", "in").
+	stateCodes map[string]bool
+}
+
+var (
+	loadOnce sync.Once
+	gaz      *gazetteer
+)
+
+func load() *gazetteer {
+	loadOnce.Do(func() {
+		g, err := parseGazetteer(citiesCSV)
+		if err != nil {
+			panic(fmt.Sprintf("location: invalid cities.csv: %v", err))
+		}
+		gaz = g
+	})
+	return gaz
+}
+
+func parseGazetteer(data string) (*gazetteer, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &gazetteer{
+		cityIndex:  map[string][]int{},
+		regions:    map[string]map[string]string{},
+		countries:  map[string]string{},
+		stateCodes: map[string]bool{},
+	}
+	addRegion := func(country, alias, canonical string) {
+		if g.regions[country] == nil {
+			g.regions[country] = map[string]string{}
+		}
+		for _, key := range foldKeys(alias) {
+			if _, ok := g.regions[country][key]; !ok {
+				g.regions[country][key] = canonical
+			}
+		}
+	}
+
+	for i, record := range records {
+		if i == 0 {
+			continue
+		}
+		if len(record) != 6 {
+			return nil, fmt.Errorf("line %d: expected 6 fields, got %d", i+1, len(record))
+		}
+		lat, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		lon, err := strconv.ParseFloat(record[5], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		place := Place{City: record[0], Region: record[2], Country: record[3], Lat: lat, Lon: lon}
+		idx := len(g.cities)
+		g.cities = append(g.cities, place)
+
+		names := []string{record[0]}
+		if record[1] != "" {
+			names = append(names, strings.Split(record[1], "|")...)
+		}
+		for _, name := range names {
+			for _, key := range foldKeys(name) {
+				if !containsIndex(g.cityIndex[key], idx) {
+					g.cityIndex[key] = append(g.cityIndex[key], idx)
+				}
+			}
+		}
+		if place.Region != "" {
+			addRegion(place.Country, place.Region, place.Region)
+		}
+	}
+
+	for country, aliases := range regionAliases {
+		for alias, canonical := range aliases {
+			addRegion(country, alias, canonical)
+			addRegion(country, canonical, canonical)
+		}
+	}
+	for code, names := range countryNames {
+		g.countries[fold(code)] = code
+		if _, ok := regionAliases["US"][code]; ok {
+			g.stateCodes[fold(code)] = true
+		}
+		for _, name := range names {
+			for _, key := range foldKeys(name) {
+				g.countries[key] = code
+			}
+		}
+	}
+	return g, nil
+}
+
+// noiseWords are dropped from location segments ("Greater Munich Metropolitan
+// Area", "Berlin (Hybrid)") before lookup.
+var noiseWords = map[string]bool{
+	"greater": true, "metropolitan": true, "metro": true, "area": true, "region": true,
+	"remote": true, "hybrid": true, "onsite": true, "homeoffice": true, "umgebung": true,
+	"und": true, "and": true, "surroundings": true,
+}
+
+// Parse normalizes a free-form location such as "Munich, Bavaria, Germany",
+// "80331 München", "Remote; Berlin" or "San Francisco, CA". countryHint (a
+// code or country name, e.g. the --country flag) breaks ties between cities
+// sharing a name. It returns false when neither a city nor a country is found.
+func Parse(raw string, countryHint string) (Place, bool) {
+	g := load()
+	segments := splitSegments(raw)
+	if len(segments) == 0 {
+		return Place{}, false
+	}
+	hintCountry := CountryCode(countryHint)
+
+	for i, segment := range segments {
+		candidates := g.lookupCity(segment)
+		if len(candidates) == 0 {
+			continue
+		}
+		hints := make([]string, 0, len(segments)-1)
+		hints = append(hints, segments[:i]...)
+		hints = append(hints, segments[i+1:]...)
+		return g.pickCity(candidates, hints, hintCountry), true
+	}
+
+	var place Place
+	for _, segment := range segments {
+		if code, ok := g.matchCountry(segment, hintCountry); ok && place.Country == "" {
+			place.Country = code
+		}
+	}
+	regionCountry := place.Country
+	if regionCountry == "" {
+		regionCountry = hintCountry
+	}
+	for _, segment := range segments {
+		country := regionCountry
+		if country == "" && g.stateCodes[segment] {
+			country = "US"
+		}
+		if region, ok := g.regions[country][segment]; ok && country != "" {
+			place.Region = region
+			place.Country = country
+			break
+		}
+	}
+	return place, place.Country != ""
+}
+
+// CountryCode maps a country code or name ("de", "usa", "Deutschland") to an
+// ISO 3166-1 alpha-2 code, or "" when unknown.
+func CountryCode(value string) string {
+	return load().countries[fold(value)]
+}
+
+// Apply fills the normalized location fields of job from job.Location when
+// they are still empty.
+func Apply(job *models.Job, countryHint string) {
+	if job == nil || job.City != "" || job.CountryCode != "" {
+		return
+	}
+	place, ok := Parse(job.Location, countryHint)
+	if !ok {
+		return
+	}
+	job.City = place.City
+	job.Region = place.Region
+	job.CountryCode = place.Country
+	job.Latitude = place.Lat
+	job.Longitude = place.Lon
+}
+
+// FromJob returns the normalized location stored on job.
+func FromJob(job models.Job) Place {
+	return Place{
+		City:    job.City,
+		Region:  job.Region,
+		Country: job.CountryCode,
+		Lat:     job.Latitude,
+		Lon:     job.Longitude,
+	}
+}
+
+// DistanceKm returns the great-circle distance between two places.
+func DistanceKm(a, b Place) float64 {
+	const earthRadiusKm = 6371.0
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+var distancePattern = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)\s*(km|kms|kilometers?|kilometres?|mi|miles?)?$`)
+
+// ParseDistance converts "50km", "50 km", "30mi" or a bare "50" (kilometres) to kilometres.
+func ParseDistance(value string) (float64, error) {
+	match := distancePattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, fmt.Errorf("invalid distance %q: expected e.g. 50km or 30mi", value)
+	}
+	amount, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("invalid distance %q: expected a positive amount", value)
+	}
+	if strings.HasPrefix(match[2], "mi") {
+		amount *= 1.609344
+	}
+	return amount, nil
+}
+
+func (g *gazetteer) lookupCity(segment string) []int {
+	if ids := g.cityIndex[segment]; len(ids) > 0 {
+		return ids
+	}
+	// Longest leading word run first, so "Frankfurt Rhine-Main" and
+	// "München-Schwabing" resolve to their city.
+	words := strings.Fields(segment)
+	for n := len(words) - 1; n > 0; n-- {
+		if ids := g.cityIndex[strings.Join(words[:n], " ")]; len(ids) > 0 {
+			return ids
+		}
+	}
+	return nil
+}
+
+// pickCity prefers the candidate whose region or country appears in the other
+// segments, then the one in hintCountry, then the first (largest) listed city.
+func (g *gazetteer) pickCity(candidates []int, hints []string, hintCountry string) Place {
+	best, bestScore := candidates[0], -1
+	for _, idx := range candidates {
+		city := g.cities[idx]
+		score := 0
+		for _, hint := range hints {
+			if region, ok := g.regions[city.Country][hint]; ok && region == city.Region {
+				score += 2
+			}
+			if code, ok := g.matchCountry(hint, hintCountry); ok && code == city.Country {
+				score += 2
+			}
+		}
+		if hintCountry != "" && hintCountry == city.Country {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = idx, score
+		}
+	}
+	return g.cities[best]
+}
+
+// matchCountry resolves a location segment to a country code. A code that
+// is also a US state code ("Remote - CA", "Austin, IN") only names the country
+// when it is the hinted one; otherwise it is left to the US region lookup.
+func (g *gazetteer) matchCountry(segment string, hintCountry string) (string, bool) {
+	code, ok := g.countries[segment]
+	if !ok {
+		return "", false
+	}
+	if g.stateCodes[segment] && code != hintCountry {
+		return "", false
+	}
+	return code, true
+}
+
+func splitSegments(raw string) []string {
+	parts := strings.FieldsFunc(raw, func(r rune) bool {
+		switch r {
+		case ',', ';', '/', '|', '•', '·', '\n':
+			return true
+		}
+		return false
+	})
+	var segments []string
+	for _, part := range parts {
+		for _, piece := range strings.Split(part, " - ") {
+			words := strings.Fields(fold(piece))
+			kept := words[:0]
+			for i := 0; i < len(words); i++ {
+				word := words[i]
+				if word == "on" && i+1 < len(words) && words[i+1] == "site" {
+					i++
+					continue
+				}
+				if isPostalCode(word) || noiseWords[word] {
+					continue
+				}
+				kept = append(kept, word)
+			}
+			if len(kept) > 0 {
+				segments = append(segments, strings.Join(kept, " "))
+			}
+		}
+	}
+	return segments
+}
+
+func isPostalCode(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	foldReplacer = strings.NewReplacer(
+		"ä", "a", "ö", "o", "ü", "u", "ß", "ss", "á", "a", "à", "a", "â", "a", "ã", "a", "å", "a",
+		"ă", "a", "ą", "a", "æ", "ae", "ç", "c", "ć", "c", "č", "c", "é", "e", "è", "e", "ê", "e",
+		"ë", "e", "ę", "e", "ě", "e", "í", "i", "ì", "i", "î", "i", "ï", "i", "ı", "i", "ł", "l",
+		"ñ", "n", "ń", "n", "ó", "o", "ò", "o", "ô", "o", "õ", "o", "ø", "o", "ř", "r", "ś", "s",
+		"š", "s", "ș", "s", "ú", "u", "ù", "u", "û", "u", "ů", "u", "ý", "y", "ź", "z", "ż", "z", "ž", "z",
+	)
+	umlautReplacer = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue")
+)
+
+// fold lowercases value, strips diacritics and turns punctuation into spaces.
+func fold(value string) string {
+	value = foldReplacer.Replace(strings.ToLower(value))
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// foldKeys returns the lookup keys for a name, including the German "ae/oe/ue"
+// spelling ("Muenchen", "Koeln") of umlauts.
+func foldKeys(value string) []string {
+	keys := []string{fold(value)}
+	if alt := fold(umlautReplacer.Replace(strings.ToLower(value))); alt != keys[0] {
+		keys = append(keys, alt)
+	}
+	return keys
+}
+
+func containsIndex(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
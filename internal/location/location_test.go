@@ -0,0 +1,99 @@
+package location
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		raw     string
+		hint    string
+		city    string
+		region  string
+		country string
+	}{
+		{"Munich, Bavaria, Germany", "", "Munich", "Bavaria", "DE"},
+		{"München", "", "Munich", "Bavaria", "DE"},
+		{"80331 München", "", "Munich", "Bavaria", "DE"},
+		{"Muenchen, Bayern", "", "Munich", "Bavaria", "DE"},
+		{"Greater Munich Metropolitan Area", "", "Munich", "Bavaria", "DE"},
+		{"Remote; Berlin", "", "Berlin", "Berlin", "DE"},
+		{"Berlin (Hybrid)", "", "Berlin", "Berlin", "DE"},
+		{"Frankfurt (Oder)", "", "Frankfurt (Oder)", "Brandenburg", "DE"},
+		{"Frankfurt Rhine-Main Metropolitan Area", "", "Frankfurt am Main", "Hesse", "DE"},
+		{"Köln, Nordrhein-Westfalen", "", "Cologne", "North Rhine-Westphalia", "DE"},
+		{"San Francisco, CA", "", "San Francisco", "California", "US"},
+		{"Cambridge, MA", "", "Cambridge", "Massachusetts", "US"},
+		{"Cambridge", "", "Cambridge", "England", "GB"},
+		{"Cambridge", "usa", "Cambridge", "Massachusetts", "US"},
+		{"Zürich, Schweiz", "", "Zurich", "Zurich", "CH"},
+		{"Bavaria, Germany", "", "", "Bavaria", "DE"},
+		{"Deutschland", "", "", "", "DE"},
+		// Country codes that are also US state codes.
+		{"Remote - CA", "", "", "California", "US"},
+		{"Austin, IN", "", "Austin", "Texas", "US"},
+		{"Springfield, IL", "", "", "Illinois", "US"},
+		{"CA", "ca", "", "", "CA"},
+		{"Remote, DE", "de", "", "", "DE"},
+		{"Wilmington, DE", "usa", "", "Delaware", "US"},
+	}
+	for _, tc := range cases {
+		place, ok := Parse(tc.raw, tc.hint)
+		if !ok {
+			t.Fatalf("Parse(%q) ok = false", tc.raw)
+		}
+		if place.City != tc.city || place.Region != tc.region || place.Country != tc.country {
+			t.Fatalf("Parse(%q, %q) = %+v, want %s/%s/%s", tc.raw, tc.hint, place, tc.city, tc.region, tc.country)
+		}
+	}
+
+	for _, raw := range []string{"", "Remote", "Anywhere"} {
+		if place, ok := Parse(raw, ""); ok {
+			t.Fatalf("Parse(%q) = %+v, want no match", raw, place)
+		}
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	munich, _ := Parse("Munich", "")
+	augsburg, _ := Parse("Augsburg", "")
+	got := DistanceKm(munich, augsburg)
+	if math.Abs(got-57) > 5 {
+		t.Fatalf("DistanceKm(Munich, Augsburg) = %.1f, want about 57", got)
+	}
+}
+
+func TestParseDistance(t *testing.T) {
+	cases := map[string]float64{
+		"50km":   50,
+		"50 km":  50,
+		"50":     50,
+		"12.5km": 12.5,
+		"10mi":   16.09344,
+	}
+	for input, want := range cases {
+		got, err := ParseDistance(input)
+		if err != nil {
+			t.Fatalf("ParseDistance(%q) error = %v", input, err)
+		}
+		if math.Abs(got-want) > 0.001 {
+			t.Fatalf("ParseDistance(%q) = %v, want %v", input, got, want)
+		}
+	}
+	for _, input := range []string{"", "far", "-5km", "0"} {
+		if _, err := ParseDistance(input); err == nil {
+			t.Fatalf("ParseDistance(%q) error = nil, want error", input)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	job := models.Job{Location: "Garching bei München"}
+	Apply(&job, "de")
+	if job.City != "Garching bei München" || job.CountryCode != "DE" || job.Latitude == 0 || job.Longitude == 0 {
+		t.Fatalf("Apply() = %+v", job)
+	}
+}
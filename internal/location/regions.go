@@ -0,0 +1,97 @@
+package location
+
+// countryNames maps ISO 3166-1 alpha-2 codes to the names and aliases job sites
+// use for them. The codes themselves are matched too; codes that clash with US
+// state codes only name the country when it is hinted (see matchCountry).
+var countryNames = map[string][]string{
+	"AE": {"United Arab Emirates", "UAE"},
+	"AT": {"Austria", "Österreich"},
+	"AU": {"Australia"},
+	"BE": {"Belgium", "Belgien", "Belgique", "België"},
+	"BG": {"Bulgaria"},
+	"CA": {"Canada", "Kanada"},
+	"CH": {"Switzerland", "Schweiz", "Suisse", "Svizzera"},
+	"CZ": {"Czechia", "Czech Republic", "Tschechien"},
+	"DE": {"Germany", "Deutschland", "Allemagne", "Alemania"},
+	"DK": {"Denmark", "Dänemark", "Danmark"},
+	"EE": {"Estonia"},
+	"ES": {"Spain", "España", "Spanien", "Espagne"},
+	"FI": {"Finland", "Finnland"},
+	"FR": {"France", "Frankreich", "Francia"},
+	"GB": {"United Kingdom", "UK", "Great Britain", "England", "Scotland", "Wales", "Northern Ireland"},
+	"GR": {"Greece", "Griechenland"},
+	"HU": {"Hungary", "Ungarn"},
+	"IE": {"Ireland", "Irland"},
+	"IL": {"Israel"},
+	"IN": {"India", "Indien"},
+	"IT": {"Italy", "Italien", "Italia"},
+	"JP": {"Japan"},
+	"LT": {"Lithuania"},
+	"LU": {"Luxembourg", "Luxemburg"},
+	"NL": {"Netherlands", "The Netherlands", "Niederlande", "Nederland", "Holland"},
+	"NO": {"Norway", "Norwegen", "Norge"},
+	"PL": {"Poland", "Polen", "Polska"},
+	"PT": {"Portugal"},
+	"RO": {"Romania", "Rumänien"},
+	"RS": {"Serbia"},
+	"SE": {"Sweden", "Schweden", "Sverige"},
+	"SG": {"Singapore", "Singapur"},
+	"TR": {"Turkey", "Türkiye", "Türkei"},
+	"US": {"United States", "United States of America", "USA", "U.S.", "US", "America"},
+}
+
+// regionAliases maps alternative region names and codes to the canonical region
+// names used in cities.csv. Canonical names are indexed from the data as well.
+var regionAliases = map[string]map[string]string{
+	"DE": {
+		"Bayern":                        "Bavaria",
+		"BY":                            "Bavaria",
+		"Baden-Wuerttemberg":            "Baden-Württemberg",
+		"BW":                            "Baden-Württemberg",
+		"Nordrhein-Westfalen":           "North Rhine-Westphalia",
+		"NRW":                           "North Rhine-Westphalia",
+		"Hessen":                        "Hesse",
+		"Niedersachsen":                 "Lower Saxony",
+		"Sachsen":                       "Saxony",
+		"Sachsen-Anhalt":                "Saxony-Anhalt",
+		"Thüringen":                     "Thuringia",
+		"Rheinland-Pfalz":               "Rhineland-Palatinate",
+		"Mecklenburg-Western Pomerania": "Mecklenburg-Vorpommern",
+	},
+	"AT": {
+		"Wien":           "Vienna",
+		"Steiermark":     "Styria",
+		"Oberösterreich": "Upper Austria",
+		"Tirol":          "Tyrol",
+	},
+	"CH": {
+		"Zürich": "Zurich",
+		"ZH":     "Zurich",
+		"Genève": "Geneva",
+		"GE":     "Geneva",
+		"BS":     "Basel-Stadt",
+		"BE":     "Bern",
+		"VD":     "Vaud",
+		"ZG":     "Zug",
+		"TI":     "Ticino",
+	},
+	"CA": {
+		"ON": "Ontario",
+		"QC": "Quebec",
+		"BC": "British Columbia",
+		"AB": "Alberta",
+	},
+	"US": {
+		"AL": "Alabama", "AK": "Alaska", "AZ": "Arizona", "AR": "Arkansas", "CA": "California",
+		"CO": "Colorado", "CT": "Connecticut", "DE": "Delaware", "DC": "District of Columbia",
+		"FL": "Florida", "GA": "Georgia", "HI": "Hawaii", "ID": "Idaho", "IL": "Illinois",
+		"IN": "Indiana", "IA": "Iowa", "KS": "Kansas", "KY": "Kentucky", "LA": "Louisiana",
+		"ME": "Maine", "MD": "Maryland", "MA": "Massachusetts", "MI": "Michigan", "MN": "Minnesota",
+		"MS": "Mississippi", "MO": "Missouri", "MT": "Montana", "NE": "Nebraska", "NV": "Nevada",
+		"NH": "New Hampshire", "NJ": "New Jersey", "NM": "New Mexico", "NY": "New York",
+		"NC": "North Carolina", "ND": "North Dakota", "OH": "Ohio", "OK": "Oklahoma", "OR": "Oregon",
+		"PA": "Pennsylvania", "RI": "Rhode Island", "SC": "South Carolina", "SD": "South Dakota",
+		"TN": "Tennessee", "TX": "Texas", "UT": "Utah", "VT": "Vermont", "VA": "Virginia",
+		"WA": "Washington", "WV": "West Virginia", "WI": "Wisconsin", "WY": "Wyoming",
+	},
+}
@@ -4,17 +4,87 @@ import "time"
 
 // Job is the normalized posting returned by scrapers.
 type Job struct {
-	ID          string    `json:"id,omitempty"`
-	Site        string    `json:"site"`
-	Title       string    `json:"title"`
-	Company     string    `json:"company"`
-	Location    string    `json:"location"`
-	URL         string    `json:"url"`
-	Remote      bool      `json:"remote,omitempty"`
-	JobType     string    `json:"job_type,omitempty"`
-	Salary      string    `json:"salary,omitempty"`
-	Description string    `json:"description,omitempty"`
-	Snippet     string    `json:"snippet,omitempty"`
-	PostedAt    time.Time `json:"posted_at,omitempty"`
-	PostedAtRaw string    `json:"posted_at_raw,omitempty"`
+	ID                  string    `json:"id,omitempty"`
+	Site                string    `json:"site"`
+	Title               string    `json:"title"`
+	Company             string    `json:"company"`
+	Location            string    `json:"location"`
+	City                string    `json:"city,omitempty"`
+	Region              string    `json:"region,omitempty"`
+	CountryCode         string    `json:"country_code,omitempty"`
+	Latitude            float64   `json:"latitude,omitempty"`
+	Longitude           float64   `json:"longitude,omitempty"`
+	URL                 string    `json:"url"`
+	Remote              bool      `json:"remote,omitempty"`
+	WorkArrangement     string    `json:"work_arrangement,omitempty"`
+	JobType             string    `json:"job_type,omitempty"`
+	EmploymentType      string    `json:"employment_type,omitempty"`
+	Seniority           string    `json:"seniority,omitempty"`
+	Salary              string    `json:"salary,omitempty"`
+	SalaryMin           float64   `json:"salary_min,omitempty"`
+	SalaryMax           float64   `json:"salary_max,omitempty"`
+	SalaryCurrency      string    `json:"salary_currency,omitempty"`
+	SalaryPeriod        string    `json:"salary_period,omitempty"`
+	SalaryAnnual        float64   `json:"salary_annual,omitempty"`
+	Description         string    `json:"description,omitempty"`
+	DescriptionMarkdown string    `json:"description_markdown,omitempty"`
+	DescriptionHTML     string    `json:"description_html,omitempty"`
+	Snippet             string    `json:"snippet,omitempty"`
+	PostedAt            time.Time `json:"posted_at,omitempty"`
+	PostedAtRaw         string    `json:"posted_at_raw,omitempty"`
+	PostedAtPrecision   string    `json:"posted_at_precision,omitempty"`
+	// Agency marks a posting by a staffing agency or recruiter rather than the employer.
+	Agency bool `json:"agency,omitempty"`
+	// Sources lists every site posting merged into this job by cross-site deduplication.
+	Sources []JobSource `json:"sources,omitempty"`
+	// Queries lists the search queries that returned this job, in run order.
+	Queries []string `json:"queries,omitempty"`
+	// QueryHits lists each query together with the site that returned the job for it.
+	QueryHits []QueryHit `json:"query_hits,omitempty"`
+	// FirstSeen, LastSeen and TimesSeen are maintained by the seen history.
+	FirstSeen time.Time `json:"first_seen,omitzero"`
+	LastSeen  time.Time `json:"last_seen,omitzero"`
+	TimesSeen int       `json:"times_seen,omitempty"`
+	// Repost marks a job already in the seen history that came back with a newer posting date.
+	Repost bool `json:"repost,omitempty"`
+	// Status is the application status set with `jobcli track set`.
+	Status          string    `json:"status,omitempty"`
+	StatusNote      string    `json:"status_note,omitempty"`
+	StatusUpdatedAt time.Time `json:"status_updated_at,omitzero"`
 }
+
+// JobSource is one site posting of a deduplicated job.
+type JobSource struct {
+	Site string `json:"site"`
+	URL  string `json:"url"`
+}
+
+// QueryHit is one query and the site whose results for it included the job.
+type QueryHit struct {
+	Query string `json:"query"`
+	Site  string `json:"site"`
+}
+
+// PostedAt precisions describe how closely Job.PostedAt matches the real posting time.
+const (
+	PostedAtExact       = "exact"
+	PostedAtDay         = "day"
+	PostedAtApproximate = "approximate"
+)
+
+// Work arrangements recorded on Job.WorkArrangement. Job.Remote is true only for WorkArrangementRemote.
+const (
+	WorkArrangementRemote  = "remote"
+	WorkArrangementHybrid  = "hybrid"
+	WorkArrangementOnsite  = "onsite"
+	WorkArrangementUnknown = "unknown"
+)
+
+// Application statuses recorded on Job.Status.
+const (
+	StatusApplied      = "applied"
+	StatusInterviewing = "interviewing"
+	StatusOffer        = "offer"
+	StatusRejected     = "rejected"
+	StatusIgnored      = "ignored"
+)
@@ -0,0 +1,125 @@
+package normalize
+
+import "github.com/jimezsa/jobcli/internal/models"
+
+// WorkArrangements lists the values accepted by --arrangement.
+var WorkArrangements = []string{
+	models.WorkArrangementRemote,
+	models.WorkArrangementHybrid,
+	models.WorkArrangementOnsite,
+}
+
+// arrangementLabels maps structured site values (JSON-LD jobLocationType,
+// workplaceType fields, flag values) to work arrangements.
+var arrangementLabels = map[string]string{
+	"remote":       models.WorkArrangementRemote,
+	"telecommute":  models.WorkArrangementRemote,
+	"fully remote": models.WorkArrangementRemote,
+	"hybrid":       models.WorkArrangementHybrid,
+	"onsite":       models.WorkArrangementOnsite,
+	"on site":      models.WorkArrangementOnsite,
+	"in office":    models.WorkArrangementOnsite,
+	"office":       models.WorkArrangementOnsite,
+}
+
+// arrangementPhrases are unambiguous enough to trust anywhere, including the
+// full description. They are checked in order, so negations win over "remote".
+var arrangementPhrases = []struct {
+	arrangement string
+	keywords    []string
+}{
+	{models.WorkArrangementOnsite, []string{
+		"no remote", "not remote", "remote not possible", "no home office", "no homeoffice",
+		"kein remote", "keine remote", "kein homeoffice", "kein home office", "remote nicht möglich",
+		"homeoffice nicht möglich", "pas de télétravail", "sans télétravail", "sin teletrabajo",
+		"no teletrabajo",
+	}},
+	{models.WorkArrangementRemote, []string{
+		"fully remote", "full remote", "100% remote", "100 % remote", "remote only", "work from anywhere",
+		"100% homeoffice", "100 % homeoffice", "nur homeoffice", "nur home office", "nur remote",
+		"vollständig remote", "komplett remote", "100% télétravail", "full télétravail",
+		"totalmente remoto", "100% remoto",
+	}},
+	{models.WorkArrangementHybrid, []string{
+		"hybrid work", "hybrid working", "hybrid model", "hybrid role", "hybrid position",
+		"hybrides arbeiten", "partially remote", "partly remote", "days remote", "days from home",
+		"days in office", "days in the office", "teilweise remote", "teilweise homeoffice",
+		"teilweise home office", "remote möglich", "homeoffice möglich", "home office möglich",
+		"mobiles arbeiten", "mobile arbeit", "tage homeoffice", "télétravail partiel",
+		"télétravail possible", "trabajo híbrido", "modelo híbrido",
+	}},
+}
+
+// arrangementLabelWords are single words that only signal an arrangement in
+// short fields (location, title, snippet, badges); in long descriptions they
+// also appear as "hybrid cloud" or "remote sensing".
+var arrangementLabelWords = []struct {
+	arrangement string
+	keywords    []string
+}{
+	{models.WorkArrangementHybrid, []string{
+		"hybrid", "hybride", "híbrido", "hibrido", "homeoffice", "home office",
+	}},
+	{models.WorkArrangementRemote, []string{
+		"remote", "work from home", "wfh", "telecommute", "télétravail", "teletrabajo", "remoto",
+	}},
+	{models.WorkArrangementOnsite, []string{
+		"on site", "onsite", "in office", "in person", "vor ort", "präsenz", "sur site", "presencial",
+	}},
+}
+
+// ParseWorkArrangement maps a structured label ("TELECOMMUTE", "on-site",
+// "Hybrid") to a work arrangement, or "" when the label is not recognized.
+func ParseWorkArrangement(value string) string {
+	return arrangementLabels[normalizeLabel(value)]
+}
+
+// WorkArrangementFromText detects a work arrangement in short free text such as
+// a location, title or badge ("Munich (Hybrid)", "Homeoffice möglich", "No remote").
+func WorkArrangementFromText(value string) string {
+	return workArrangementFromText(normalizeLabel(value), true)
+}
+
+// InferWorkArrangement returns the job's work arrangement, reading the location,
+// title and snippet before the description. It never returns "".
+func InferWorkArrangement(job models.Job) string {
+	if job.WorkArrangement != "" && job.WorkArrangement != models.WorkArrangementUnknown {
+		return job.WorkArrangement
+	}
+	for _, value := range []string{job.Location, job.Title, job.Snippet} {
+		if arrangement := WorkArrangementFromText(value); arrangement != "" {
+			return arrangement
+		}
+	}
+	if arrangement := workArrangementFromText(normalizeLabel(job.Description), false); arrangement != "" {
+		return arrangement
+	}
+	if job.Remote {
+		return models.WorkArrangementRemote
+	}
+	return models.WorkArrangementUnknown
+}
+
+func workArrangementFromText(normalized string, short bool) string {
+	if normalized == "" {
+		return ""
+	}
+	for _, entry := range arrangementPhrases {
+		for _, keyword := range entry.keywords {
+			if containsWord(normalized, keyword) {
+				return entry.arrangement
+			}
+		}
+	}
+	if !short {
+		return ""
+	}
+	for _, entry := range arrangementLabelWords {
+		for _, keyword := range entry.keywords {
+			if containsWord(normalized, keyword) {
+				return entry.arrangement
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package normalize
+
+import (
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// Employment types stored on models.Job.EmploymentType and accepted by --job-type.
+const (
+	EmploymentFullTime   = "fulltime"
+	EmploymentPartTime   = "parttime"
+	EmploymentContract   = "contract"
+	EmploymentInternship = "internship"
+)
+
+// employmentKeywords maps normalized site labels (JSON-LD employmentType, German,
+// French and Spanish labels) to employment types.
+var employmentKeywords = []struct {
+	keyword    string
+	employment string
+}{
+	{"full time", EmploymentFullTime},
+	{"fulltime", EmploymentFullTime},
+	{"vollzeit", EmploymentFullTime},
+	{"temps plein", EmploymentFullTime},
+	{"tiempo completo", EmploymentFullTime},
+	{"part time", EmploymentPartTime},
+	{"parttime", EmploymentPartTime},
+	{"teilzeit", EmploymentPartTime},
+	{"temps partiel", EmploymentPartTime},
+	{"medio tiempo", EmploymentPartTime},
+	{"media jornada", EmploymentPartTime},
+	{"contractor", EmploymentContract},
+	{"contract", EmploymentContract},
+	{"freelance", EmploymentContract},
+	{"freiberuflich", EmploymentContract},
+	{"befristet", EmploymentContract},
+	{"temporary", EmploymentContract},
+	{"internship", EmploymentInternship},
+	{"intern", EmploymentInternship},
+	{"praktikum", EmploymentInternship},
+	{"werkstudent", EmploymentInternship},
+	{"stage", EmploymentInternship},
+	{"prácticas", EmploymentInternship},
+	{"practicas", EmploymentInternship},
+}
+
+// titleEmploymentKeywords are title words that reliably imply an employment type.
+var titleEmploymentKeywords = []struct {
+	keyword    string
+	employment string
+}{
+	{"internship", EmploymentInternship},
+	{"intern", EmploymentInternship},
+	{"praktikum", EmploymentInternship},
+	{"praktikant", EmploymentInternship},
+	{"werkstudent", EmploymentInternship},
+	{"working student", EmploymentInternship},
+	{"part time", EmploymentPartTime},
+	{"teilzeit", EmploymentPartTime},
+	{"freelance", EmploymentContract},
+	{"contractor", EmploymentContract},
+}
+
+// ParseEmploymentType maps a single site label ("FULL_TIME", "Vollzeit",
+// "Praktikum") to one of the employment type constants.
+func ParseEmploymentType(value string) string {
+	normalized := normalizeLabel(value)
+	if normalized == "" {
+		return ""
+	}
+	for _, entry := range employmentKeywords {
+		if containsWord(normalized, entry.keyword) {
+			return entry.employment
+		}
+	}
+	return ""
+}
+
+// EmploymentTypes returns the employment types a posting declares, falling back
+// to title keywords ("Werkstudent", "Intern", "Teilzeit") when the site gave none.
+func EmploymentTypes(job models.Job) []string {
+	var types []string
+	add := func(employment string) {
+		if employment == "" {
+			return
+		}
+		for _, existing := range types {
+			if existing == employment {
+				return
+			}
+		}
+		types = append(types, employment)
+	}
+
+	for _, part := range strings.FieldsFunc(job.JobType, func(r rune) bool {
+		return r == ',' || r == ';' || r == '/' || r == '|'
+	}) {
+		add(ParseEmploymentType(part))
+	}
+	if len(types) > 0 {
+		return types
+	}
+
+	title := normalizeLabel(job.Title)
+	for _, entry := range titleEmploymentKeywords {
+		if containsWord(title, entry.keyword) {
+			add(entry.employment)
+		}
+	}
+	return types
+}
+
+func normalizeLabel(value string) string {
+	value = strings.ToLower(value)
+	value = strings.NewReplacer("_", " ", "-", " ", "(", " ", ")", " ").Replace(value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func containsWord(value string, word string) bool {
+	for start := 0; start < len(value); {
+		idx := strings.Index(value[start:], word)
+		if idx < 0 {
+			return false
+		}
+		idx += start
+		end := idx + len(word)
+		if (idx == 0 || !isWordByte(value[idx-1])) && (end == len(value) || !isWordByte(value[end])) {
+			return true
+		}
+		start = idx + 1
+	}
+	return false
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b >= 0x80
+}
@@ -0,0 +1,21 @@
+package normalize
+
+import "github.com/jimezsa/jobcli/internal/models"
+
+// Apply fills job.EmploymentType and job.Seniority when they are still empty and
+// resolves job.WorkArrangement, keeping job.Remote in sync with it.
+func Apply(job *models.Job) {
+	if job == nil {
+		return
+	}
+	if job.EmploymentType == "" {
+		if types := EmploymentTypes(*job); len(types) > 0 {
+			job.EmploymentType = types[0]
+		}
+	}
+	if job.Seniority == "" {
+		job.Seniority = InferSeniority(*job)
+	}
+	job.WorkArrangement = InferWorkArrangement(*job)
+	job.Remote = job.WorkArrangement == models.WorkArrangementRemote
+}
@@ -0,0 +1,118 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestParseEmploymentType(t *testing.T) {
+	cases := map[string]string{
+		"FULL_TIME":  EmploymentFullTime,
+		"Full-time":  EmploymentFullTime,
+		"Vollzeit":   EmploymentFullTime,
+		"PART_TIME":  EmploymentPartTime,
+		"Teilzeit":   EmploymentPartTime,
+		"CONTRACTOR": EmploymentContract,
+		"INTERN":     EmploymentInternship,
+		"Praktikum":  EmploymentInternship,
+		"OTHER":      "",
+		"":           "",
+	}
+	for input, want := range cases {
+		if got := ParseEmploymentType(input); got != want {
+			t.Fatalf("ParseEmploymentType(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestEmploymentTypes_DoesNotMatchSubstrings(t *testing.T) {
+	job := models.Job{Title: "Internal Tools Engineer"}
+	if got := EmploymentTypes(job); len(got) != 0 {
+		t.Fatalf("EmploymentTypes() = %v, want none", got)
+	}
+}
+
+func TestInferSeniority(t *testing.T) {
+	cases := []struct {
+		job  models.Job
+		want string
+	}{
+		{models.Job{Title: "Werkstudent Software Engineering (m/w/d)"}, SeniorityIntern},
+		{models.Job{Title: "Data Analyst", JobType: "INTERN"}, SeniorityIntern},
+		{models.Job{Title: "Junior Frontend Developer"}, SeniorityJunior},
+		{models.Job{Title: "Sr. Backend Engineer"}, SenioritySenior},
+		{models.Job{Title: "Staff Software Engineer"}, SeniorityStaff},
+		{models.Job{Title: "Principal Data Scientist"}, SeniorityStaff},
+		{models.Job{Title: "Tech Lead Platform"}, SeniorityLead},
+		{models.Job{Title: "Lead Backend Engineer"}, SeniorityLead},
+		{models.Job{Title: "Platform Lead (m/w/d)"}, SeniorityLead},
+		{models.Job{Title: "Lead Generation Specialist"}, ""},
+		{models.Job{Title: "Early Stage Investor"}, ""},
+		{models.Job{Title: "Stage de fin d'études - Développeur"}, SeniorityIntern},
+		{models.Job{Title: "Stagiaire Data Analyst"}, SeniorityIntern},
+		{models.Job{Title: "Senior Engineering Manager"}, SeniorityManager},
+		{models.Job{Title: "Head of Data"}, SeniorityManager},
+		{models.Job{Title: "Mid-Level Go Developer"}, SeniorityMid},
+		{models.Job{Title: "Mid-Market Account Executive"}, ""},
+		{models.Job{Title: "Staff Accountant"}, ""},
+		{models.Job{Title: "Nursing Staff (m/w/d)"}, ""},
+		{models.Job{Title: "Product Manager"}, ""},
+		{models.Job{Title: "International Sales Engineer"}, ""},
+		{models.Job{Title: "Backend Engineer", Description: "You have 5-7 years of professional experience."}, SenioritySenior},
+		{models.Job{Title: "Backend Engineer", Description: "Mindestens 3 Jahre Berufserfahrung"}, SeniorityMid},
+		{models.Job{Title: "Backend Engineer", Snippet: "Great for entry-level candidates"}, SeniorityJunior},
+		{models.Job{Title: "Backend Engineer", Description: "Founded 20 years ago in Berlin."}, ""},
+	}
+	for _, tc := range cases {
+		if got := InferSeniority(tc.job); got != tc.want {
+			t.Fatalf("InferSeniority(%q) = %q, want %q", tc.job.Title, got, tc.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	job := models.Job{Title: "Senior Engineer", JobType: "FULL_TIME, CONTRACTOR"}
+	Apply(&job)
+	if job.EmploymentType != EmploymentFullTime || job.Seniority != SenioritySenior {
+		t.Fatalf("Apply() = %q/%q, want fulltime/senior", job.EmploymentType, job.Seniority)
+	}
+}
+
+func TestInferWorkArrangement(t *testing.T) {
+	cases := []struct {
+		job  models.Job
+		want string
+	}{
+		{models.Job{Location: "Remote; Berlin"}, models.WorkArrangementRemote},
+		{models.Job{Location: "Munich, Bavaria, Germany (Hybrid)"}, models.WorkArrangementHybrid},
+		{models.Job{Location: "Berlin", Snippet: "Homeoffice möglich"}, models.WorkArrangementHybrid},
+		{models.Job{Location: "Berlin", Snippet: "Teilweise remote, 2 Tage im Büro"}, models.WorkArrangementHybrid},
+		{models.Job{Location: "Hamburg", Snippet: "No remote, sorry."}, models.WorkArrangementOnsite},
+		{models.Job{Location: "Austin, TX (On-site)"}, models.WorkArrangementOnsite},
+		{models.Job{Title: "Go Developer (100% Remote)"}, models.WorkArrangementRemote},
+		{models.Job{Location: "Berlin", Description: "Experience with hybrid cloud and remote sensing data."}, models.WorkArrangementUnknown},
+		{models.Job{Location: "Berlin", Description: "We offer a hybrid working model."}, models.WorkArrangementHybrid},
+		{models.Job{Location: "Berlin", WorkArrangement: models.WorkArrangementRemote}, models.WorkArrangementRemote},
+		{models.Job{Location: "Berlin", Remote: true}, models.WorkArrangementRemote},
+	}
+	for _, tc := range cases {
+		if got := InferWorkArrangement(tc.job); got != tc.want {
+			t.Fatalf("InferWorkArrangement(%+v) = %q, want %q", tc.job, got, tc.want)
+		}
+	}
+}
+
+func TestParseWorkArrangement(t *testing.T) {
+	cases := map[string]string{
+		"TELECOMMUTE": models.WorkArrangementRemote,
+		"on-site":     models.WorkArrangementOnsite,
+		"Hybrid":      models.WorkArrangementHybrid,
+		"unspecified": "",
+	}
+	for input, want := range cases {
+		if got := ParseWorkArrangement(input); got != want {
+			t.Fatalf("ParseWorkArrangement(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
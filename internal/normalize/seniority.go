@@ -0,0 +1,195 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// Seniority levels stored on models.Job.Seniority and accepted by --seniority.
+const (
+	SeniorityIntern  = "intern"
+	SeniorityJunior  = "junior"
+	SeniorityMid     = "mid"
+	SenioritySenior  = "senior"
+	SeniorityStaff   = "staff"
+	SeniorityLead    = "lead"
+	SeniorityManager = "manager"
+)
+
+// Seniorities lists every seniority level from least to most senior.
+var Seniorities = []string{
+	SeniorityIntern,
+	SeniorityJunior,
+	SeniorityMid,
+	SenioritySenior,
+	SeniorityStaff,
+	SeniorityLead,
+	SeniorityManager,
+}
+
+// titleSeniorityKeywords are checked in order, so "Senior Engineering Manager"
+// is a manager and "Werkstudent Senior Consultant" is still an intern. Words
+// with a common non-role meaning only count in role phrasing: French "stage"
+// ("Stage de fin d'études", not "Early Stage Investor"), "lead" ("Tech Lead",
+// "Lead Engineer", not "Lead Generation Specialist"; see isLeadTitle), "staff"
+// ("Staff Engineer", not "Staff Accountant" or "Nursing Staff"; see
+// isStaffTitle) and "mid" ("Mid-Level", not "Mid-Market Account Executive").
+var titleSeniorityKeywords = []struct {
+	seniority string
+	keywords  []string
+}{
+	{SeniorityIntern, []string{
+		"intern", "internship", "praktikum", "praktikant", "praktikantin", "werkstudent",
+		"werkstudentin", "working student", "student assistant", "studentische hilfskraft",
+		"hiwi", "stagiaire", "stage de", "stage en", "stage h/f", "alternance", "becario", "becaria", "prácticas",
+		"practicas", "apprentice", "apprenticeship", "ausbildung", "azubi",
+	}},
+	{SeniorityManager, []string{
+		"engineering manager", "development manager", "team manager", "people manager",
+		"general manager", "managing director", "head of", "director", "vp", "vice president",
+		"chief", "cto", "cio", "abteilungsleiter", "abteilungsleiterin", "bereichsleiter",
+		"bereichsleiterin", "geschäftsführer", "geschäftsführerin", "leiter", "leiterin",
+		"directeur", "directrice", "director de", "jefe de",
+	}},
+	{SeniorityLead, []string{
+		"tech lead", "team lead", "technical lead", "engineering lead", "development lead",
+		"teamleiter", "teamleiterin", "teamleitung", "chef d'équipe", "líder",
+	}},
+	{SeniorityStaff, []string{"principal", "distinguished"}},
+	{SenioritySenior, []string{"senior", "sr", "snr", "sénior", "confirmé", "confirmée"}},
+	{SeniorityJunior, []string{
+		"junior", "jr", "entry level", "graduate", "new grad", "absolvent", "absolventin",
+		"berufseinsteiger", "berufseinsteigerin", "einsteiger", "trainee", "débutant",
+	}},
+	{SeniorityMid, []string{"mid level", "midlevel", "mid senior", "intermediate", "medior"}},
+}
+
+// descriptionJuniorPhrases mark entry-level roles in the posting body.
+var descriptionJuniorPhrases = []string{
+	"entry level", "new grad", "recent graduate", "berufseinsteiger", "berufseinsteigerin",
+	"berufsanfänger", "jeune diplômé", "recién graduado",
+}
+
+// experiencePattern matches "3+ years of experience", "5-7 years of professional
+// experience", "mindestens 3 Jahre Berufserfahrung" or "2 ans d'expérience".
+var experiencePattern = regexp.MustCompile(`(\d{1,2})\s*\+?\s*(?:(?:–|to|bis)?\s*\d{1,2}\s*\+?\s*)?(?:years?|yrs?|jahren?|jahre|ans?|años?)\b(?:\s+\S+){0,4}?\s*(?:\S*erfahrung|experience|expérience|d'expérience|experiencia)`)
+
+// ParseSeniority maps a seniority label to one of the seniority constants.
+func ParseSeniority(value string) string {
+	normalized := normalizeLabel(value)
+	for _, seniority := range Seniorities {
+		if normalized == seniority {
+			return seniority
+		}
+	}
+	return ""
+}
+
+// InferSeniority derives a seniority level from the title, the employment type
+// and finally the stated years of experience in the description.
+func InferSeniority(job models.Job) string {
+	title := normalizeLabel(job.Title)
+	for _, entry := range titleSeniorityKeywords {
+		for _, keyword := range entry.keywords {
+			if containsWord(title, keyword) {
+				return entry.seniority
+			}
+		}
+		if entry.seniority == SeniorityLead && isLeadTitle(title) {
+			return SeniorityLead
+		}
+		if entry.seniority == SeniorityStaff && isStaffTitle(title) {
+			return SeniorityStaff
+		}
+	}
+	for _, employment := range EmploymentTypes(job) {
+		if employment == EmploymentInternship {
+			return SeniorityIntern
+		}
+	}
+
+	description := normalizeLabel(firstNonEmpty(job.Description, job.Snippet))
+	if description == "" {
+		return ""
+	}
+	for _, phrase := range descriptionJuniorPhrases {
+		if containsWord(description, phrase) {
+			return SeniorityJunior
+		}
+	}
+	if match := experiencePattern.FindStringSubmatch(description); match != nil {
+		years, err := strconv.Atoi(match[1])
+		if err == nil {
+			return seniorityForYears(years)
+		}
+	}
+	return ""
+}
+
+// roleWords are the roles a leading "Lead" or "Staff" qualifies ("Lead
+// Engineer", "Staff Data Scientist").
+var roleWords = map[string]bool{
+	"engineer": true, "developer": true, "software": true, "data": true, "architect": true,
+	"designer": true, "consultant": true, "scientist": true, "analyst": true, "devops": true,
+	"backend": true, "frontend": true, "fullstack": true, "full": true, "product": true,
+	"qa": true, "test": true, "ux": true, "ui": true, "cloud": true, "platform": true,
+	"security": true, "sre": true, "ml": true, "machine": true, "ai": true, "mobile": true,
+	"ios": true, "android": true, "java": true, "python": true, "go": true, "programmer": true,
+	"researcher": true, "entwickler": true, "entwicklerin": true, "ingenieur": true,
+	"ingenieurin": true,
+}
+
+// isLeadTitle reports whether a normalized title names a lead role: "lead"
+// followed by a role ("Lead Backend Engineer") or as the last word before any
+// gender marker ("Platform Lead (m/w/d)").
+func isLeadTitle(title string) bool {
+	words := strings.Fields(title)
+	for len(words) > 0 && strings.Contains(words[len(words)-1], "/") {
+		words = words[:len(words)-1]
+	}
+	for i, word := range words {
+		if word != "lead" {
+			continue
+		}
+		if i == len(words)-1 || roleWords[words[i+1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// isStaffTitle reports whether a normalized title names a staff-level role:
+// "staff" followed by a role ("Staff Software Engineer"). Unlike "lead", a
+// trailing "staff" is a group of employees ("Nursing Staff").
+func isStaffTitle(title string) bool {
+	words := strings.Fields(title)
+	for i := 0; i+1 < len(words); i++ {
+		if words[i] == "staff" && roleWords[words[i+1]] {
+			return true
+		}
+	}
+	return false
+}
+
+func seniorityForYears(years int) string {
+	switch {
+	case years < 2:
+		return SeniorityJunior
+	case years < 5:
+		return SeniorityMid
+	default:
+		return SenioritySenior
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
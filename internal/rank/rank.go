@@ -0,0 +1,210 @@
+package rank
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jimezsa/jobcli/internal/models"
+	"github.com/jimezsa/jobcli/internal/salary"
+)
+
+// Key is a sort order for search results.
+type Key string
+
+const (
+	KeyPostedAt Key = "posted_at"
+	KeySite     Key = "site"
+	KeyCompany  Key = "company"
+	KeyTitle    Key = "title"
+	KeySalary   Key = "salary"
+	KeyScore    Key = "score"
+)
+
+// Keys lists the accepted sort keys.
+var Keys = []Key{KeyPostedAt, KeySite, KeyCompany, KeyTitle, KeySalary, KeyScore}
+
+// ParseKey parses a sort key; the empty string is the default site order.
+func ParseKey(value string) (Key, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return KeySite, nil
+	}
+	for _, key := range Keys {
+		if string(key) == value {
+			return key, nil
+		}
+	}
+	names := make([]string, len(Keys))
+	for i, key := range Keys {
+		names[i] = string(key)
+	}
+	return "", fmt.Errorf("unknown sort key %q (want one of %s)", value, strings.Join(names, ", "))
+}
+
+// Sort orders jobs by key, descending when desc is set. Jobs without a
+// posting date or salary sort last in either direction. Ties are broken by
+// site and then keep their incoming order, so equal runs give equal output.
+// queries feed the score key.
+func Sort(jobs []models.Job, key Key, desc bool, queries []string) {
+	var values []float64
+	switch key {
+	case KeyPostedAt, KeySalary, KeyScore:
+		values = make([]float64, len(jobs))
+		terms := queryTerms(queries)
+		for i, job := range jobs {
+			values[i] = numericValue(job, key, terms)
+		}
+	}
+
+	index := make([]int, len(jobs))
+	for i := range index {
+		index[i] = i
+	}
+	sort.SliceStable(index, func(a, b int) bool {
+		x, y := jobs[index[a]], jobs[index[b]]
+		var cmp int
+		if values != nil {
+			cmp = compareNumbers(values[index[a]], values[index[b]], desc, key != KeyScore)
+		} else {
+			cmp = strings.Compare(textValue(x, key), textValue(y, key))
+			if desc {
+				cmp = -cmp
+			}
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+		return strings.ToLower(x.Site) < strings.ToLower(y.Site)
+	})
+
+	sorted := make([]models.Job, len(jobs))
+	for i, idx := range index {
+		sorted[i] = jobs[idx]
+	}
+	copy(jobs, sorted)
+}
+
+// Boost moves jobs for which boosted reports true to the front, keeping the
+// existing order within both parts.
+func Boost(jobs []models.Job, boosted func(models.Job) bool) {
+	front := make([]models.Job, 0, len(jobs))
+	var back []models.Job
+	for _, job := range jobs {
+		if boosted(job) {
+			front = append(front, job)
+		} else {
+			back = append(back, job)
+		}
+	}
+	copy(jobs, append(front, back...))
+}
+
+// compareNumbers compares two sort values. When zeroLast is set, zero means
+// unknown and sorts after every known value.
+func compareNumbers(x, y float64, desc bool, zeroLast bool) int {
+	if zeroLast && (x == 0) != (y == 0) {
+		if x == 0 {
+			return 1
+		}
+		return -1
+	}
+	cmp := 0
+	switch {
+	case x < y:
+		cmp = -1
+	case x > y:
+		cmp = 1
+	}
+	if desc {
+		cmp = -cmp
+	}
+	return cmp
+}
+
+func numericValue(job models.Job, key Key, terms [][]string) float64 {
+	switch key {
+	case KeyPostedAt:
+		if job.PostedAt.IsZero() {
+			return 0
+		}
+		return float64(job.PostedAt.Unix())
+	case KeySalary:
+		// Compare in one currency when the rate is known.
+		if converted, ok := salary.Convert(job.SalaryAnnual, job.SalaryCurrency, "EUR"); ok {
+			return converted
+		}
+		return job.SalaryAnnual
+	default:
+		return scoreTerms(job, terms)
+	}
+}
+
+func textValue(job models.Job, key Key) string {
+	var value string
+	switch key {
+	case KeyCompany:
+		value = job.Company
+	case KeyTitle:
+		value = job.Title
+	default:
+		value = job.Site
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// Score rates how well job matches the best of queries, from 0 to 1: the
+// share of query words found in the title, with a smaller weight for words
+// found only in the snippet or description.
+func Score(job models.Job, queries []string) float64 {
+	return scoreTerms(job, queryTerms(queries))
+}
+
+func scoreTerms(job models.Job, terms [][]string) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+	title := wordSet(job.Title)
+	body := wordSet(job.Snippet + " " + job.Description)
+	best := 0.0
+	for _, query := range terms {
+		score := 0.0
+		for _, term := range query {
+			switch {
+			case title[term]:
+				score += 1
+			case body[term]:
+				score += 0.25
+			}
+		}
+		if score /= float64(len(query)); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+func queryTerms(queries []string) [][]string {
+	var terms [][]string
+	for _, query := range queries {
+		if words := words(query); len(words) > 0 {
+			terms = append(terms, words)
+		}
+	}
+	return terms
+}
+
+func wordSet(value string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range words(value) {
+		set[word] = true
+	}
+	return set
+}
+
+func words(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
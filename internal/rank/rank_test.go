@@ -0,0 +1,90 @@
+package rank
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func titles(jobs []models.Job) string {
+	names := make([]string, len(jobs))
+	for i, job := range jobs {
+		names[i] = job.Title
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSort(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	newJobs := func() []models.Job {
+		return []models.Job{
+			{Site: "stepstone", Title: "a", Company: "Beta", PostedAt: day(3), SalaryAnnual: 70000, SalaryCurrency: "EUR"},
+			{Site: "linkedin", Title: "b", Company: "acme"},
+			{Site: "indeed", Title: "c", Company: "Acme", PostedAt: day(5), SalaryAnnual: 70000, SalaryCurrency: "USD"},
+			{Site: "google", Title: "d", Company: "Gamma", PostedAt: day(3), SalaryAnnual: 90000, SalaryCurrency: "XYZ"},
+		}
+	}
+
+	tests := []struct {
+		key  Key
+		desc bool
+		want string
+	}{
+		{key: KeySite, want: "d,c,b,a"},
+		{key: KeyPostedAt, want: "d,a,c,b"},
+		{key: KeyPostedAt, desc: true, want: "c,d,a,b"},
+		{key: KeyCompany, want: "c,b,a,d"},
+		{key: KeyTitle, desc: true, want: "d,c,b,a"},
+		{key: KeySalary, desc: true, want: "d,a,c,b"},
+		{key: KeySalary, want: "c,a,d,b"},
+	}
+	for _, tt := range tests {
+		jobs := newJobs()
+		Sort(jobs, tt.key, tt.desc, nil)
+		if got := titles(jobs); got != tt.want {
+			t.Fatalf("Sort(%s, desc=%t) = %s, want %s", tt.key, tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestSortByScore(t *testing.T) {
+	jobs := []models.Job{
+		{Site: "indeed", Title: "Sales Manager"},
+		{Site: "linkedin", Title: "Platform Engineer", Snippet: "Backend services"},
+		{Site: "google", Title: "Senior Backend Engineer"},
+	}
+	Sort(jobs, KeyScore, true, []string{"backend engineer", "sre"})
+	if got := titles(jobs); got != "Senior Backend Engineer,Platform Engineer,Sales Manager" {
+		t.Fatalf("Sort(score) = %s", got)
+	}
+	if got := Score(jobs[1], []string{"backend engineer"}); got != 0.625 {
+		t.Fatalf("Score() = %v, want 0.625", got)
+	}
+}
+
+func TestBoost(t *testing.T) {
+	jobs := []models.Job{
+		{Title: "A", Company: "Acme"},
+		{Title: "B", Company: "Google"},
+		{Title: "C", Company: "Acme"},
+		{Title: "D", Company: "Google"},
+	}
+	Boost(jobs, func(job models.Job) bool { return job.Company == "Google" })
+	if got := titles(jobs); got != "B,D,A,C" {
+		t.Fatalf("Boost() = %s, want B,D,A,C", got)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	if key, err := ParseKey(""); err != nil || key != KeySite {
+		t.Fatalf("ParseKey(\"\") = %q, %v; want site", key, err)
+	}
+	if key, err := ParseKey(" Posted_At "); err != nil || key != KeyPostedAt {
+		t.Fatalf("ParseKey(Posted_At) = %q, %v", key, err)
+	}
+	if _, err := ParseKey("date"); err == nil {
+		t.Fatalf("ParseKey(date) error = nil, want error")
+	}
+}
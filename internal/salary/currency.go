@@ -0,0 +1,39 @@
+package salary
+
+import "strings"
+
+// eurRates is a static table of approximate EUR values for one unit of each
+// currency. It only needs to be good enough for threshold filtering.
+var eurRates = map[string]float64{
+	"EUR": 1,
+	"USD": 0.92,
+	"GBP": 1.17,
+	"CHF": 1.04,
+	"CAD": 0.68,
+	"AUD": 0.61,
+	"INR": 0.011,
+	"SEK": 0.088,
+	"NOK": 0.086,
+	"DKK": 0.134,
+	"PLN": 0.23,
+	"JPY": 0.0062,
+}
+
+// Convert converts amount between currencies using the static rate table.
+// Unknown currencies are reported with ok=false.
+func Convert(amount float64, from string, to string) (float64, bool) {
+	from = strings.ToUpper(strings.TrimSpace(from))
+	to = strings.ToUpper(strings.TrimSpace(to))
+	if from == to {
+		return amount, true
+	}
+	fromRate, ok := eurRates[from]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := eurRates[to]
+	if !ok {
+		return 0, false
+	}
+	return amount * fromRate / toRate, true
+}
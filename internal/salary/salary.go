@@ -0,0 +1,312 @@
+package salary
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// Pay periods recorded on models.Job.SalaryPeriod.
+const (
+	PeriodHour  = "hour"
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// annualFactors converts an amount per period into a yearly amount
+// (40h weeks, 5-day weeks, 52 weeks).
+var annualFactors = map[string]float64{
+	PeriodHour:  2080,
+	PeriodDay:   260,
+	PeriodWeek:  52,
+	PeriodMonth: 12,
+	PeriodYear:  1,
+}
+
+// Salary is a parsed pay range. Min or Max may be zero for open-ended ranges.
+type Salary struct {
+	Min      float64
+	Max      float64
+	Currency string
+	Period   string
+}
+
+var (
+	amountPattern   = regexp.MustCompile(`(?i)(\d{1,3}(?:[.,\x{00a0}\x{202f} ]\d{3})+|\d+)(?:[.,](\d{1,2}))?(?:\s*(k)\b)?`)
+	currencyPattern = regexp.MustCompile(`(?i)\b(usd|eur|gbp|chf|cad|aud|inr|sek|nok|dkk|pln|jpy)\b`)
+)
+
+var currencySymbols = []struct {
+	symbol   string
+	currency string
+}{
+	{"ca$", "CAD"},
+	{"c$", "CAD"},
+	{"au$", "AUD"},
+	{"a$", "AUD"},
+	{"$", "USD"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"₹", "INR"},
+	{"¥", "JPY"},
+}
+
+// periodKeywords are checked in order against the words of the lowercased
+// salary text (see periodTokens), so "mes" does not match "Mesa" and "day"
+// does not match "Monday". A "/" is its own token: "/ h" matches "€25/h".
+var periodKeywords = []struct {
+	keyword string
+	period  string
+}{
+	{"hour", PeriodHour}, {"hours", PeriodHour}, {"hourly", PeriodHour}, {"/ hr", PeriodHour},
+	{"/ h", PeriodHour}, {"stunde", PeriodHour}, {"stunden", PeriodHour}, {"std", PeriodHour},
+	{"stündlich", PeriodHour}, {"heure", PeriodHour}, {"heures", PeriodHour}, {"hora", PeriodHour},
+	{"horas", PeriodHour},
+	{"day", PeriodDay}, {"days", PeriodDay}, {"daily", PeriodDay}, {"/ tag", PeriodDay},
+	{"pro tag", PeriodDay}, {"täglich", PeriodDay}, {"jour", PeriodDay}, {"día", PeriodDay},
+	{"week", PeriodWeek}, {"weeks", PeriodWeek}, {"weekly", PeriodWeek}, {"woche", PeriodWeek},
+	{"wöchentlich", PeriodWeek}, {"semaine", PeriodWeek}, {"semana", PeriodWeek},
+	{"month", PeriodMonth}, {"months", PeriodMonth}, {"monthly", PeriodMonth}, {"/ mo", PeriodMonth},
+	{"monat", PeriodMonth}, {"monatlich", PeriodMonth}, {"monatsgehalt", PeriodMonth},
+	{"mois", PeriodMonth}, {"mensuel", PeriodMonth}, {"mes", PeriodMonth}, {"mensual", PeriodMonth},
+	{"year", PeriodYear}, {"years", PeriodYear}, {"yearly", PeriodYear}, {"annum", PeriodYear},
+	{"annual", PeriodYear}, {"annually", PeriodYear}, {"/ yr", PeriodYear}, {"jahr", PeriodYear},
+	{"jährlich", PeriodYear}, {"jahresgehalt", PeriodYear}, {"p a", PeriodYear},
+	{"par an", PeriodYear}, {"/ an", PeriodYear}, {"annuel", PeriodYear}, {"año", PeriodYear},
+	{"anual", PeriodYear},
+}
+
+// Parse extracts a pay range from free-form text such as "50.000 - 70.000 EUR",
+// "$60–$75 an hour", "€55K - €65K a year" or "Up to £40,000 per annum".
+func Parse(raw string) (Salary, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Salary{}, false
+	}
+	lower := strings.ToLower(raw)
+
+	var amounts []float64
+	for _, match := range amountPattern.FindAllStringSubmatch(raw, -1) {
+		amount, ok := parseAmount(match[1], match[2], match[3] != "")
+		if !ok {
+			continue
+		}
+		amounts = append(amounts, amount)
+		if len(amounts) == 2 {
+			break
+		}
+	}
+	if len(amounts) == 0 {
+		return Salary{}, false
+	}
+
+	s := Salary{Currency: parseCurrency(lower)}
+	switch {
+	case len(amounts) == 1 && hasPrefixWord(lower, "up to", "bis zu", "jusqu'à", "hasta"):
+		s.Max = amounts[0]
+	case len(amounts) == 1:
+		s.Min = amounts[0]
+	default:
+		s.Min, s.Max = amounts[0], amounts[1]
+		if s.Max < s.Min {
+			s.Min, s.Max = s.Max, s.Min
+		}
+	}
+	s.Period = parsePeriod(lower, s.upper())
+	return s, true
+}
+
+// FromAmounts builds a salary from already structured values (e.g. JSON-LD
+// baseSalary) where unit names such as "YEAR" or "HOUR" are used.
+func FromAmounts(min, max float64, currency string, unit string) Salary {
+	s := Salary{Min: min, Max: max, Currency: strings.ToUpper(strings.TrimSpace(currency))}
+	if s.Max > 0 && s.Min > s.Max {
+		s.Min, s.Max = s.Max, s.Min
+	}
+	s.Period = parsePeriod(strings.ToLower(unit), s.upper())
+	return s
+}
+
+// Annual returns the range converted to a yearly amount.
+func (s Salary) Annual() (float64, float64) {
+	factor, ok := annualFactors[s.Period]
+	if !ok {
+		factor = 1
+	}
+	return s.Min * factor, s.Max * factor
+}
+
+// AnnualEstimate returns the yearly midpoint of the range, or the single bound when open-ended.
+func (s Salary) AnnualEstimate() float64 {
+	min, max := s.Annual()
+	switch {
+	case min > 0 && max > 0:
+		return (min + max) / 2
+	case max > 0:
+		return max
+	default:
+		return min
+	}
+}
+
+// Apply fills the structured salary fields from job.Salary when they are still empty.
+func Apply(job *models.Job) {
+	if job == nil || job.SalaryMin > 0 || job.SalaryMax > 0 {
+		return
+	}
+	s, ok := Parse(job.Salary)
+	if !ok {
+		return
+	}
+	Set(job, s)
+}
+
+// Set stores s on job, including the annualized estimate.
+func Set(job *models.Job, s Salary) {
+	if job == nil || (s.Min <= 0 && s.Max <= 0) {
+		return
+	}
+	job.SalaryMin = s.Min
+	job.SalaryMax = s.Max
+	job.SalaryCurrency = s.Currency
+	job.SalaryPeriod = s.Period
+	job.SalaryAnnual = roundCents(s.AnnualEstimate())
+}
+
+// FromJob returns the structured salary stored on job.
+func FromJob(job models.Job) (Salary, bool) {
+	if job.SalaryMin <= 0 && job.SalaryMax <= 0 {
+		return Salary{}, false
+	}
+	return Salary{
+		Min:      job.SalaryMin,
+		Max:      job.SalaryMax,
+		Currency: job.SalaryCurrency,
+		Period:   job.SalaryPeriod,
+	}, true
+}
+
+func (s Salary) upper() float64 {
+	if s.Max > s.Min {
+		return s.Max
+	}
+	return s.Min
+}
+
+func parseAmount(integer string, decimals string, thousands bool) (float64, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, integer)
+	if decimals != "" {
+		digits += "." + decimals
+	}
+	value, err := strconv.ParseFloat(digits, 64)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	if thousands {
+		value *= 1000
+	}
+	return value, true
+}
+
+func parseCurrency(lower string) string {
+	if match := currencyPattern.FindStringSubmatch(lower); match != nil {
+		return strings.ToUpper(match[1])
+	}
+	for _, entry := range currencySymbols {
+		if strings.Contains(lower, entry.symbol) {
+			return entry.currency
+		}
+	}
+	if strings.Contains(lower, "euro") {
+		return "EUR"
+	}
+	return ""
+}
+
+// parsePeriod reads the pay period from text, falling back to the magnitude
+// of the amount when no period is stated.
+func parsePeriod(lower string, amount float64) string {
+	tokens := periodTokens(lower)
+	for _, entry := range periodKeywords {
+		if containsTokens(tokens, strings.Fields(entry.keyword)) {
+			return entry.period
+		}
+	}
+	switch {
+	case amount <= 0:
+		return ""
+	case amount < 300:
+		return PeriodHour
+	case amount < 15000:
+		return PeriodMonth
+	default:
+		return PeriodYear
+	}
+}
+
+// periodTokens splits text into words and "/" separators; other punctuation
+// only separates words.
+func periodTokens(lower string) []string {
+	var tokens []string
+	start := -1
+	for i, r := range lower {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, lower[start:i])
+			start = -1
+		}
+		if r == '/' {
+			tokens = append(tokens, "/")
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, lower[start:])
+	}
+	return tokens
+}
+
+// containsTokens reports whether phrase occurs as a run of whole tokens.
+func containsTokens(tokens, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		matched := true
+		for j, word := range phrase {
+			if tokens[i+j] != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefixWord(lower string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
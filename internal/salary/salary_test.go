@@ -0,0 +1,78 @@
+package salary
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want Salary
+	}{
+		{"50.000 - 70.000 EUR", Salary{Min: 50000, Max: 70000, Currency: "EUR", Period: PeriodYear}},
+		{"$60–$75 an hour", Salary{Min: 60, Max: 75, Currency: "USD", Period: PeriodHour}},
+		{"€55K - €65K a year", Salary{Min: 55000, Max: 65000, Currency: "EUR", Period: PeriodYear}},
+		{"Up to £40,000 per annum", Salary{Max: 40000, Currency: "GBP", Period: PeriodYear}},
+		{"100000 - 150000 USD", Salary{Min: 100000, Max: 150000, Currency: "USD", Period: PeriodYear}},
+		{"4.500 € pro Monat", Salary{Min: 4500, Currency: "EUR", Period: PeriodMonth}},
+		{"CHF 110 000", Salary{Min: 110000, Currency: "CHF", Period: PeriodYear}},
+		{"$25.50/hr", Salary{Min: 25.5, Currency: "USD", Period: PeriodHour}},
+		{"€25/h", Salary{Min: 25, Currency: "EUR", Period: PeriodHour}},
+		{"55.000 € p.a.", Salary{Min: 55000, Currency: "EUR", Period: PeriodYear}},
+		{"60.000 € Jahresgehalt", Salary{Min: 60000, Currency: "EUR", Period: PeriodYear}},
+		// Period words only match whole words.
+		{"$120,000 Mesa office", Salary{Min: 120000, Currency: "USD", Period: PeriodYear}},
+		{"€5.000 standard package", Salary{Min: 5000, Currency: "EUR", Period: PeriodMonth}},
+		{"$90,000, starts Monday", Salary{Min: 90000, Currency: "USD", Period: PeriodYear}},
+	}
+
+	for _, tc := range cases {
+		got, ok := Parse(tc.raw)
+		if !ok {
+			t.Fatalf("Parse(%q) ok = false", tc.raw)
+		}
+		if got != tc.want {
+			t.Fatalf("Parse(%q) = %+v, want %+v", tc.raw, got, tc.want)
+		}
+	}
+}
+
+func TestParse_RejectsTextWithoutAmounts(t *testing.T) {
+	for _, raw := range []string{"", "Competitive", "Gehalt anzeigen"} {
+		if _, ok := Parse(raw); ok {
+			t.Fatalf("Parse(%q) ok = true, want false", raw)
+		}
+	}
+}
+
+func TestApplySetsAnnualEstimate(t *testing.T) {
+	job := models.Job{Salary: "$60–$70 an hour"}
+	Apply(&job)
+	if job.SalaryMin != 60 || job.SalaryMax != 70 || job.SalaryCurrency != "USD" || job.SalaryPeriod != PeriodHour {
+		t.Fatalf("unexpected structured salary: %+v", job)
+	}
+	if job.SalaryAnnual != 135200 {
+		t.Fatalf("SalaryAnnual = %v, want 135200", job.SalaryAnnual)
+	}
+}
+
+func TestFromAmounts(t *testing.T) {
+	got := FromAmounts(5000, 6000, "eur", "MONTH")
+	want := Salary{Min: 5000, Max: 6000, Currency: "EUR", Period: PeriodMonth}
+	if got != want {
+		t.Fatalf("FromAmounts() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	got, ok := Convert(100, "USD", "EUR")
+	if !ok || math.Abs(got-92) > 0.001 {
+		t.Fatalf("Convert(USD->EUR) = %v, %v", got, ok)
+	}
+	if _, ok := Convert(100, "XYZ", "EUR"); ok {
+		t.Fatalf("Convert() with unknown currency ok = true, want false")
+	}
+}
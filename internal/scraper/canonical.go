@@ -0,0 +1,156 @@
+package scraper
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+var stepstoneJobIDPattern = regexp.MustCompile(`--(\d+)(?:-inline)?\.html$`)
+
+// trackingParams are query parameters that only identify the search or click
+// and never the job itself; utm_* parameters are dropped as well.
+var trackingParams = map[string]bool{
+	"refid": true, "trackingid": true, "trk": true, "trkinfo": true, "position": true,
+	"pagenum": true, "from": true, "fbclid": true, "gclid": true, "msclkid": true,
+	"mc_cid": true, "mc_eid": true,
+}
+
+// applyJobIdentity fills job.ID with the site-native job ID and rewrites
+// job.URL to a canonical form without tracking parameters, so the same
+// posting keeps one URL from run to run. An ID found in the URL wins over one
+// already set from the card or JSON-LD.
+func applyJobIdentity(job *models.Job) {
+	if job == nil {
+		return
+	}
+	rawURL := strings.TrimSpace(job.URL)
+	if rawURL == "" {
+		return
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return
+	}
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Fragment = ""
+
+	var id string
+	switch job.Site {
+	case SiteLinkedIn:
+		id = firstNonEmpty(linkedInJobID(rawURL), job.ID)
+		if id != "" {
+			job.ID = id
+			job.URL = "https://www.linkedin.com/jobs/view/" + id
+			return
+		}
+	case SiteIndeed:
+		query := parsed.Query()
+		id = firstNonEmpty(query.Get("jk"), query.Get("vjk"), job.ID)
+		if id != "" {
+			job.ID = id
+			job.URL = (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: "/viewjob", RawQuery: "jk=" + url.QueryEscape(id)}).String()
+			return
+		}
+	case SiteGlassdoor:
+		query := parsed.Query()
+		if id = firstNonEmpty(query.Get("jl"), query.Get("jobListingId")); id != "" {
+			job.ID = id
+			parsed.RawQuery = "jl=" + url.QueryEscape(id)
+			job.URL = parsed.String()
+			return
+		}
+	case SiteZipRecruiter:
+		if id = parsed.Query().Get("jid"); id != "" {
+			job.ID = id
+			parsed.RawQuery = "jid=" + url.QueryEscape(id)
+			job.URL = parsed.String()
+			return
+		}
+	case SiteStepstone:
+		if match := stepstoneJobIDPattern.FindStringSubmatch(parsed.Path); match != nil {
+			job.ID = match[1]
+		}
+		parsed.RawQuery = ""
+		job.URL = parsed.String()
+		return
+	case SiteGoogleJobs:
+		if id = parsed.Query().Get("htidocid"); id != "" {
+			job.ID = id
+			parsed.RawQuery = filterRawQuery(parsed.RawQuery, func(key string) bool {
+				return key == "q" || key == "ibp" || key == "htidocid"
+			})
+			job.URL = parsed.String()
+			return
+		}
+	}
+
+	parsed.RawQuery = filterRawQuery(parsed.RawQuery, func(key string) bool {
+		key = strings.ToLower(key)
+		return !trackingParams[key] && !strings.HasPrefix(key, "utm_")
+	})
+	job.URL = parsed.String()
+}
+
+// filterRawQuery keeps the query pairs whose key passes keep, leaving their
+// encoding untouched (Google's "ibp=htl;jobs" does not survive url.Values).
+func filterRawQuery(rawQuery string, keep func(key string) bool) string {
+	var kept []string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(pair, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if keep(key) {
+			kept = append(kept, pair)
+		}
+	}
+	return strings.Join(kept, "&")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// siteHosts maps host name fragments to the site that serves them.
+var siteHosts = []struct {
+	fragment string
+	site     string
+}{
+	{"linkedin.", SiteLinkedIn},
+	{"indeed.", SiteIndeed},
+	{"glassdoor.", SiteGlassdoor},
+	{"ziprecruiter.", SiteZipRecruiter},
+	{"stepstone.", SiteStepstone},
+	{"google.", SiteGoogleJobs},
+}
+
+// Identify infers the site of a job URL from its host and returns the site,
+// the site-native job ID and the canonical URL the scrapers would record.
+// Unknown hosts only get tracking parameters stripped.
+func Identify(rawURL string) (site string, id string, canonicalURL string) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", "", rawURL
+	}
+	host := strings.ToLower(parsed.Host)
+	for _, candidate := range siteHosts {
+		if strings.Contains(host, candidate.fragment) {
+			site = candidate.site
+			break
+		}
+	}
+	job := models.Job{Site: site, URL: rawURL}
+	applyJobIdentity(&job)
+	return site, job.ID, job.URL
+}
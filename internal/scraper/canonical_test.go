@@ -0,0 +1,129 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestApplyJobIdentity(t *testing.T) {
+	cases := []struct {
+		site   string
+		rawURL string
+		id     string
+		url    string
+	}{
+		{
+			SiteLinkedIn,
+			"https://de.linkedin.com/jobs/view/electronics-engineer-f-m-d-at-omnisent-4361039203?position=1&pageNum=0&refId=abc&trackingId=xyz",
+			"4361039203",
+			"https://www.linkedin.com/jobs/view/4361039203",
+		},
+		{
+			SiteIndeed,
+			"https://de.indeed.com/rc/clk?jk=7f3a9c1b2d4e5f60&fccid=123&vjs=3&from=serp",
+			"7f3a9c1b2d4e5f60",
+			"https://de.indeed.com/viewjob?jk=7f3a9c1b2d4e5f60",
+		},
+		{
+			SiteIndeed,
+			"https://www.indeed.com/jobs?q=golang&vjk=abc123def456",
+			"abc123def456",
+			"https://www.indeed.com/viewjob?jk=abc123def456",
+		},
+		{
+			SiteGlassdoor,
+			"https://www.glassdoor.com/job-listing/senior-dev-acme-JV_IC1147401_KO0,10_KE11,15.htm?jl=1009012345&pos=101&ao=1136043&guid=abc",
+			"1009012345",
+			"https://www.glassdoor.com/job-listing/senior-dev-acme-JV_IC1147401_KO0,10_KE11,15.htm?jl=1009012345",
+		},
+		{
+			SiteZipRecruiter,
+			"https://www.ziprecruiter.com/c/Acme/Job/Platform-Engineer/-in-Austin,TX?jid=5f1e2d3c4b5a6978&lvk=tracking",
+			"5f1e2d3c4b5a6978",
+			"https://www.ziprecruiter.com/c/Acme/Job/Platform-Engineer/-in-Austin,TX?jid=5f1e2d3c4b5a6978",
+		},
+		{
+			SiteStepstone,
+			"https://www.stepstone.de/stellenangebote--Software-Engineer-Muenchen-ACME-GmbH--12345678-inline.html?rltr=2_2_25_seorl_m_0_0_0_0_0_0#top",
+			"12345678",
+			"https://www.stepstone.de/stellenangebote--Software-Engineer-Muenchen-ACME-GmbH--12345678-inline.html",
+		},
+		{
+			SiteGoogleJobs,
+			"https://www.google.com/search?q=sre+jobs&ibp=htl;jobs&sa=X&ved=abc&htidocid=doc123",
+			"doc123",
+			"https://www.google.com/search?q=sre+jobs&ibp=htl;jobs&htidocid=doc123",
+		},
+		{
+			"company",
+			"https://careers.example.com/jobs/42?utm_source=linkedin&utm_medium=jobs&lang=en",
+			"",
+			"https://careers.example.com/jobs/42?lang=en",
+		},
+	}
+	for _, tc := range cases {
+		job := models.Job{Site: tc.site, URL: tc.rawURL}
+		applyJobIdentity(&job)
+		if job.ID != tc.id || job.URL != tc.url {
+			t.Fatalf("applyJobIdentity(%s, %q) = %q, %q; want %q, %q", tc.site, tc.rawURL, job.ID, job.URL, tc.id, tc.url)
+		}
+	}
+}
+
+func TestApplyJobIdentity_KeepsCardID(t *testing.T) {
+	job := models.Job{Site: SiteIndeed, ID: "abc123", URL: "https://www.indeed.com/pagead/clk?mo=r&ad=-6NYlbfkN0&from=serp"}
+	applyJobIdentity(&job)
+	if job.ID != "abc123" || job.URL != "https://www.indeed.com/viewjob?jk=abc123" {
+		t.Fatalf("applyJobIdentity() = %q, %q", job.ID, job.URL)
+	}
+}
+
+func TestParseLinkedInJobs_CanonicalURL(t *testing.T) {
+	html := `
+<ul>
+  <li>
+    <div class="base-card" data-entity-urn="urn:li:jobPosting:4361039203">
+      <a class="base-card__full-link" href="https://de.linkedin.com/jobs/view/platform-engineer-at-acme-4361039203?refId=abc&trackingId=xyz&position=3"></a>
+      <h3 class="base-search-card__title">Platform Engineer</h3>
+      <h4 class="base-search-card__subtitle">Acme</h4>
+    </div>
+  </li>
+</ul>`
+
+	jobs := parseLinkedInJobs(mustDoc(t, html))
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	if jobs[0].ID != "4361039203" || jobs[0].URL != "https://www.linkedin.com/jobs/view/4361039203" {
+		t.Fatalf("unexpected identity: %q %q", jobs[0].ID, jobs[0].URL)
+	}
+}
+
+func TestJSONLDIdentifier(t *testing.T) {
+	html := `
+<script type="application/ld+json">
+{"@type":"JobPosting","title":"SRE","hiringOrganization":{"name":"Acme"},
+ "identifier":{"@type":"PropertyValue","name":"Acme","value":"REQ-42"},
+ "url":"https://careers.example.com/jobs/42?utm_source=google"}
+</script>`
+
+	jobs := parseJSONLDJobs(mustDoc(t, html), SiteGoogleJobs)
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	if jobs[0].ID != "REQ-42" || jobs[0].URL != "https://careers.example.com/jobs/42" {
+		t.Fatalf("unexpected identity: %q %q", jobs[0].ID, jobs[0].URL)
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	site, id, canonical := Identify("https://de.indeed.com/viewjob?jk=7f3a9c1b2d4e5f60&from=serp&vjs=3")
+	if site != SiteIndeed || id != "7f3a9c1b2d4e5f60" || canonical != "https://de.indeed.com/viewjob?jk=7f3a9c1b2d4e5f60" {
+		t.Fatalf("Identify() = %q, %q, %q", site, id, canonical)
+	}
+	site, id, canonical = Identify("https://careers.example.com/jobs/42?utm_source=x")
+	if site != "" || id != "" || canonical != "https://careers.example.com/jobs/42" {
+		t.Fatalf("Identify() = %q, %q, %q", site, id, canonical)
+	}
+}
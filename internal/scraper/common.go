@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"html"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 	fhttp "github.com/bogdanfinn/fhttp"
 	"github.com/jimezsa/jobcli/internal/models"
 	"github.com/jimezsa/jobcli/internal/network"
+	"github.com/jimezsa/jobcli/internal/normalize"
+	"github.com/jimezsa/jobcli/internal/salary"
 )
 
 func fetchDocument(ctx context.Context, client *network.Client, target string, headers map[string]string) (*goquery.Document, error) {
@@ -91,6 +94,12 @@ func parsePostedAt(value string) (time.Time, error) {
 		time.RFC3339Nano,
 		"2006-01-02",
 		"2006-01-02T15:04:05-0700",
+		"2006-01-02 15:04:05",
+		"02.01.2006",
+		"Jan 2, 2006",
+		"January 2, 2006",
+		"2 Jan 2006",
+		"2 January 2006",
 	}
 	for _, layout := range layouts {
 		if ts, err := time.Parse(layout, value); err == nil {
@@ -201,21 +210,32 @@ func jobFromJobPosting(value map[string]any, site string) models.Job {
 	job.Title = stringValue(value["title"], value["name"])
 	job.Company = stringValue(mapValue(value["hiringOrganization"], "name"))
 	job.URL = stringValue(value["url"], value["@id"])
+	job.ID = identifierFromJSONLD(value["identifier"])
 	job.JobType = stringValue(value["employmentType"])
 	job.Salary = salaryFromJSONLD(value["baseSalary"])
-	job.PostedAtRaw = stringValue(value["datePosted"])
-	if job.PostedAtRaw != "" {
-		if ts, err := parsePostedAt(job.PostedAtRaw); err == nil {
-			job.PostedAt = ts
-		}
+	if details, ok := salaryDetailsFromJSONLD(value["baseSalary"]); ok {
+		salary.Set(&job, details)
 	}
+	job.PostedAtRaw = stringValue(value["datePosted"])
+	applyPostedAt(&job, time.Now())
 	job.Location = locationFromJSONLD(value["jobLocation"])
-	job.Description = cleanText(stringValue(value["description"]))
+	applyDescription(&job, descriptionFromHTML(stringValue(value["description"])))
 	job.Snippet = truncate(job.Description, 240)
-	job.Remote = strings.Contains(strings.ToLower(job.Location), "remote")
+	job.WorkArrangement = normalize.ParseWorkArrangement(stringValue(value["jobLocationType"]))
+	applyWorkArrangement(&job)
+	applyJobIdentity(&job)
 	return job
 }
 
+// identifierFromJSONLD reads a JobPosting identifier, either a plain value or a
+// PropertyValue whose "name" is the organization rather than the ID.
+func identifierFromJSONLD(value any) string {
+	if v, ok := value.(map[string]any); ok {
+		return stringValue(v["value"])
+	}
+	return stringValue(value)
+}
+
 func salaryFromJSONLD(value any) string {
 	if value == nil {
 		return ""
@@ -241,6 +261,46 @@ func salaryFromJSONLD(value any) string {
 	return ""
 }
 
+// salaryDetailsFromJSONLD reads the structured MonetaryAmount/QuantitativeValue
+// fields that salaryFromJSONLD flattens into a display string.
+func salaryDetailsFromJSONLD(value any) (salary.Salary, bool) {
+	v, ok := value.(map[string]any)
+	if !ok {
+		return salary.Salary{}, false
+	}
+
+	currency := stringValue(v["currency"])
+	unit := stringValue(v["unitText"])
+	var min, max float64
+	switch quantity := v["value"].(type) {
+	case map[string]any:
+		min = numberValue(quantity["minValue"])
+		max = numberValue(quantity["maxValue"])
+		if min == 0 && max == 0 {
+			min = numberValue(quantity["value"])
+		}
+		unit = stringValue(quantity["unitText"], unit)
+	default:
+		min = numberValue(quantity)
+	}
+	if min <= 0 && max <= 0 {
+		return salary.Salary{}, false
+	}
+	return salary.FromAmounts(min, max, currency, unit), true
+}
+
+func numberValue(value any) float64 {
+	raw := stringValue(value)
+	if raw == "" {
+		return 0
+	}
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
+
 func locationFromJSONLD(value any) string {
 	if value == nil {
 		return ""
@@ -338,6 +398,13 @@ func truncate(value string, max int) string {
 	return strings.TrimSpace(value[:max]) + "..."
 }
 
+// applyWorkArrangement resolves job.WorkArrangement from any site signal already
+// set and the job's text fields, and keeps job.Remote in sync with it.
+func applyWorkArrangement(job *models.Job) {
+	job.WorkArrangement = normalize.InferWorkArrangement(*job)
+	job.Remote = job.WorkArrangement == models.WorkArrangementRemote
+}
+
 func filterRemote(jobs []models.Job) []models.Job {
 	filtered := jobs[:0]
 	for _, job := range jobs {
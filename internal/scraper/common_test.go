@@ -120,6 +120,9 @@ func TestJSONLDSalaryAndLocation(t *testing.T) {
 	if job.Salary == "" || !strings.Contains(job.Salary, "USD") {
 		t.Fatalf("expected salary with currency, got %q", job.Salary)
 	}
+	if job.SalaryMin != 100000 || job.SalaryMax != 150000 || job.SalaryCurrency != "USD" || job.SalaryPeriod != "year" {
+		t.Fatalf("unexpected structured salary: min=%v max=%v currency=%q period=%q", job.SalaryMin, job.SalaryMax, job.SalaryCurrency, job.SalaryPeriod)
+	}
 	if !strings.Contains(job.Location, "Denver") {
 		t.Fatalf("expected location to include city, got %q", job.Location)
 	}
@@ -131,6 +134,26 @@ func TestJSONLDSalaryAndLocation(t *testing.T) {
 	}
 }
 
+func TestJSONLDJobLocationType(t *testing.T) {
+	job := jobFromJobPosting(map[string]any{
+		"title":           "SRE",
+		"jobLocationType": "TELECOMMUTE",
+		"jobLocation":     map[string]any{"address": map[string]any{"addressLocality": "Berlin"}},
+	}, SiteStepstone)
+	if job.WorkArrangement != models.WorkArrangementRemote || !job.Remote {
+		t.Fatalf("arrangement = %q remote = %v, want remote", job.WorkArrangement, job.Remote)
+	}
+
+	job = jobFromJobPosting(map[string]any{
+		"title":       "SRE",
+		"jobLocation": map[string]any{"address": map[string]any{"addressLocality": "Berlin"}},
+		"description": "<p>No remote work, you join us in the office.</p>",
+	}, SiteStepstone)
+	if job.WorkArrangement != models.WorkArrangementOnsite || job.Remote {
+		t.Fatalf("arrangement = %q remote = %v, want onsite", job.WorkArrangement, job.Remote)
+	}
+}
+
 func TestDedupeAndFilterRemote(t *testing.T) {
 	jobs := []models.Job{
 		{Site: "x", Title: "A", Company: "C", Location: "Remote", URL: "https://example.com/a", Remote: true},
@@ -90,14 +90,16 @@ func parseGlassdoorJobs(doc *goquery.Document) []models.Job {
 		}
 
 		job := models.Job{
+			ID:       cleanText(s.AttrOr("data-id", "")),
 			Site:     SiteGlassdoor,
 			Title:    title,
 			Company:  company,
 			Location: location,
 			URL:      link,
 			Salary:   salary,
-			Remote:   isRemote(location, ""),
 		}
+		applyWorkArrangement(&job)
+		applyJobIdentity(&job)
 
 		jobs = append(jobs, job)
 	})
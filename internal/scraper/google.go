@@ -98,6 +98,7 @@ func parseGoogleJobsAnchors(doc *goquery.Document) []models.Job {
 			Title: title,
 			URL:   absoluteURL("https://www.google.com", href),
 		}
+		applyJobIdentity(&job)
 		jobs = append(jobs, job)
 	})
 
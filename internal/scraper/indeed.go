@@ -6,6 +6,7 @@ import (
 	"math"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/jimezsa/jobcli/internal/models"
@@ -32,6 +33,7 @@ func (i *Indeed) Search(ctx context.Context, params models.SearchParams) ([]mode
 	}
 
 	var jobs []models.Job
+	now := time.Now()
 	doc.Find("a.tapItem").Each(func(_ int, s *goquery.Selection) {
 		if params.Limit > 0 && len(jobs) >= params.Limit {
 			return
@@ -42,6 +44,7 @@ func (i *Indeed) Search(ctx context.Context, params models.SearchParams) ([]mode
 		location := strings.TrimSpace(s.Find("div.companyLocation").First().Text())
 		snippet := strings.TrimSpace(s.Find("div.job-snippet").Text())
 		posted := strings.TrimSpace(s.Find("span.date").Text())
+		salary := normalizeSnippet(s.Find("div.salary-snippet-container, div.salary-snippet, span.estimated-salary").First().Text())
 
 		link, _ := s.Attr("href")
 		if link != "" && !strings.HasPrefix(link, "http") {
@@ -49,16 +52,19 @@ func (i *Indeed) Search(ctx context.Context, params models.SearchParams) ([]mode
 		}
 
 		job := models.Job{
+			ID:          strings.TrimSpace(s.AttrOr("data-jk", "")),
 			Site:        SiteIndeed,
 			Title:       title,
 			Company:     company,
 			Location:    location,
 			URL:         link,
 			Snippet:     normalizeSnippet(snippet),
+			Salary:      salary,
 			PostedAtRaw: posted,
-			Remote:      isRemote(location, snippet),
-			JobType:     params.JobType,
 		}
+		applyPostedAt(&job, now)
+		applyWorkArrangement(&job)
+		applyJobIdentity(&job)
 
 		if params.Remote && !job.Remote {
 			return
@@ -108,8 +114,3 @@ func baseIndeedURL(country string) string {
 func normalizeSnippet(value string) string {
 	return strings.Join(strings.Fields(value), " ")
 }
-
-func isRemote(location string, snippet string) bool {
-	value := strings.ToLower(location + " " + snippet)
-	return strings.Contains(value, "remote")
-}
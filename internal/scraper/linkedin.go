@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/jimezsa/jobcli/internal/models"
@@ -55,7 +56,7 @@ func (l *LinkedIn) Search(ctx context.Context, params models.SearchParams) ([]mo
 				continue
 			}
 			if job.Description == "" {
-				job.Description = l.fetchLinkedInDescription(ctx, job.URL)
+				applyDescription(&job, l.fetchLinkedInDescription(ctx, job.URL))
 			}
 			if job.Description == "" {
 				job.Description = job.Snippet
@@ -88,6 +89,7 @@ func buildLinkedInURL(params models.SearchParams, start int) string {
 func parseLinkedInJobs(doc *goquery.Document) []models.Job {
 	var jobs []models.Job
 	seen := map[string]struct{}{}
+	now := time.Now()
 
 	doc.Find("li").Each(func(_ int, s *goquery.Selection) {
 		link := attrFirst(s, "a.base-card__full-link", "href")
@@ -109,7 +111,10 @@ func parseLinkedInJobs(doc *goquery.Document) []models.Job {
 			postedRaw = cleanText(s.Find("time").First().Text())
 		}
 
+		urn := s.AttrOr("data-entity-urn", attrFirst(s, "[data-entity-urn]", "data-entity-urn"))
+
 		job := models.Job{
+			ID:          lastLinkedInIDMatch(urn),
 			Site:        SiteLinkedIn,
 			Title:       title,
 			Company:     company,
@@ -117,13 +122,10 @@ func parseLinkedInJobs(doc *goquery.Document) []models.Job {
 			URL:         link,
 			Snippet:     snippet,
 			PostedAtRaw: postedRaw,
-			Remote:      isRemote(location, snippet),
-		}
-		if job.PostedAtRaw != "" {
-			if ts, err := parsePostedAt(job.PostedAtRaw); err == nil {
-				job.PostedAt = ts
-			}
 		}
+		applyPostedAt(&job, now)
+		applyWorkArrangement(&job)
+		applyJobIdentity(&job)
 
 		key := job.URL
 		if _, ok := seen[key]; ok {
@@ -143,15 +145,15 @@ func attrFirst(s *goquery.Selection, selector string, attr string) string {
 	return s.Find(selector).First().AttrOr(attr, "")
 }
 
-func (l *LinkedIn) fetchLinkedInDescription(ctx context.Context, rawJobURL string) string {
+func (l *LinkedIn) fetchLinkedInDescription(ctx context.Context, rawJobURL string) jobDescription {
 	detailURL := linkedInDetailURL(rawJobURL)
 	if detailURL == "" {
-		return ""
+		return jobDescription{}
 	}
 
 	doc, err := fetchDocument(ctx, l.client, detailURL, nil)
 	if err != nil {
-		return ""
+		return jobDescription{}
 	}
 	return parseLinkedInDescription(doc)
 }
@@ -207,12 +209,12 @@ func lastLinkedInIDMatch(value string) string {
 	return matches[len(matches)-1]
 }
 
-func parseLinkedInDescription(doc *goquery.Document) string {
+func parseLinkedInDescription(doc *goquery.Document) jobDescription {
 	if doc == nil {
-		return ""
+		return jobDescription{}
 	}
 
-	return firstText(
+	return firstDescription(
 		doc.Selection,
 		"div.show-more-less-html__markup",
 		"section.show-more-less-html",
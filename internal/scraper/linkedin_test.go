@@ -89,7 +89,7 @@ func TestLinkedInDetailURL(t *testing.T) {
 func TestParseLinkedInDescription(t *testing.T) {
 	html := `<div class="show-more-less-html__markup">Build APIs for distributed systems.</div>`
 	doc := mustDoc(t, html)
-	got := parseLinkedInDescription(doc)
+	got := parseLinkedInDescription(doc).Text
 	if got != "Build APIs for distributed systems." {
 		t.Fatalf("unexpected description: %q", got)
 	}
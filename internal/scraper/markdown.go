@@ -0,0 +1,324 @@
+package scraper
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/jimezsa/jobcli/internal/models"
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// jobDescription holds a posting description in each supported representation.
+type jobDescription struct {
+	Text     string
+	Markdown string
+	HTML     string
+}
+
+// applyDescription fills the job description fields that are still empty.
+func applyDescription(job *models.Job, desc jobDescription) {
+	if job.Description == "" {
+		job.Description = desc.Text
+	}
+	if job.DescriptionMarkdown == "" {
+		job.DescriptionMarkdown = desc.Markdown
+	}
+	if job.DescriptionHTML == "" {
+		job.DescriptionHTML = desc.HTML
+	}
+}
+
+// firstDescription returns the first non-empty description matched by selectors.
+func firstDescription(s *goquery.Selection, selectors ...string) jobDescription {
+	if s == nil {
+		return jobDescription{}
+	}
+	for _, selector := range selectors {
+		raw, err := s.Find(selector).First().Html()
+		if err != nil {
+			continue
+		}
+		if desc := descriptionFromHTML(raw); desc.Text != "" {
+			return desc
+		}
+	}
+	return jobDescription{}
+}
+
+// descriptionFromHTML converts an HTML fragment into text, Markdown and trimmed HTML.
+// JSON-LD payloads often carry entity-escaped markup, which is unescaped first.
+func descriptionFromHTML(raw string) jobDescription {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return jobDescription{}
+	}
+	if !strings.Contains(raw, "<") && strings.Contains(raw, "&lt;") {
+		raw = strings.TrimSpace(html.UnescapeString(raw))
+	}
+
+	nodes, err := xhtml.ParseFragment(strings.NewReader(raw), &xhtml.Node{
+		Type:     xhtml.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		text := cleanText(raw)
+		return jobDescription{Text: text, Markdown: text, HTML: raw}
+	}
+
+	var text strings.Builder
+	for _, node := range nodes {
+		writePlainText(&text, node)
+	}
+
+	conv := &markdownConverter{}
+	for _, node := range nodes {
+		conv.block(node)
+	}
+	conv.flush()
+
+	return jobDescription{
+		Text:     cleanText(text.String()),
+		Markdown: strings.Join(conv.blocks, "\n\n"),
+		HTML:     raw,
+	}
+}
+
+func writePlainText(b *strings.Builder, n *xhtml.Node) {
+	switch n.Type {
+	case xhtml.TextNode:
+		b.WriteString(n.Data)
+		return
+	case xhtml.ElementNode:
+		if isSkippedElement(n) {
+			return
+		}
+	}
+
+	block := n.Type == xhtml.ElementNode && isBlockElement(n)
+	if block {
+		b.WriteByte(' ')
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writePlainText(b, child)
+	}
+	if block {
+		b.WriteByte(' ')
+	}
+}
+
+type markdownConverter struct {
+	blocks []string
+	inline strings.Builder
+}
+
+func (c *markdownConverter) flush() {
+	text := collapseMarkdownLines(c.inline.String())
+	c.inline.Reset()
+	if text != "" {
+		c.blocks = append(c.blocks, text)
+	}
+}
+
+func (c *markdownConverter) appendBlock(value string) {
+	c.flush()
+	if value = strings.TrimSpace(value); value != "" {
+		c.blocks = append(c.blocks, value)
+	}
+}
+
+func (c *markdownConverter) block(n *xhtml.Node) {
+	switch n.Type {
+	case xhtml.TextNode:
+		c.inline.WriteString(n.Data)
+		return
+	case xhtml.ElementNode:
+	default:
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			c.block(child)
+		}
+		return
+	}
+
+	if isSkippedElement(n) {
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		if title := collapseSpaces(inlineMarkdown(n)); title != "" {
+			c.appendBlock(strings.Repeat("#", level) + " " + title)
+		}
+	case atom.Ul, atom.Ol:
+		c.appendBlock(renderMarkdownList(n, 0))
+	case atom.Hr:
+		c.appendBlock("---")
+	case atom.Br:
+		c.inline.WriteByte('\n')
+	default:
+		if !isBlockElement(n) {
+			c.inline.WriteString(inlineMarkdown(n))
+			return
+		}
+		c.flush()
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			c.block(child)
+		}
+		c.flush()
+	}
+}
+
+func renderMarkdownList(n *xhtml.Node, depth int) string {
+	ordered := n.DataAtom == atom.Ol
+	index := 1
+	var lines []string
+
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != xhtml.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+
+		var (
+			text   strings.Builder
+			nested []string
+		)
+		for child := item.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == xhtml.ElementNode && (child.DataAtom == atom.Ul || child.DataAtom == atom.Ol) {
+				if list := renderMarkdownList(child, depth+1); list != "" {
+					nested = append(nested, list)
+				}
+				continue
+			}
+			text.WriteString(inlineMarkdown(child))
+		}
+
+		marker := "-"
+		if ordered {
+			marker = fmt.Sprintf("%d.", index)
+			index++
+		}
+		if line := collapseSpaces(text.String()); line != "" {
+			lines = append(lines, strings.Repeat("  ", depth)+marker+" "+line)
+		}
+		lines = append(lines, nested...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func inlineMarkdown(n *xhtml.Node) string {
+	switch n.Type {
+	case xhtml.TextNode:
+		return n.Data
+	case xhtml.ElementNode:
+	default:
+		return inlineChildren(n)
+	}
+
+	if isSkippedElement(n) {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\n"
+	case atom.Strong, atom.B:
+		return wrapInline("**", inlineChildren(n))
+	case atom.Em, atom.I:
+		return wrapInline("_", inlineChildren(n))
+	case atom.Code:
+		return wrapInline("`", inlineChildren(n))
+	case atom.A:
+		label := inlineChildren(n)
+		href := strings.TrimSpace(attrValue(n, "href"))
+		if !isMarkdownLinkTarget(href) || strings.TrimSpace(label) == "" {
+			return label
+		}
+		return "[" + strings.TrimSpace(label) + "](" + href + ")"
+	case atom.Ul, atom.Ol:
+		return " " + strings.ReplaceAll(renderMarkdownList(n, 0), "\n", " ") + " "
+	}
+
+	if isBlockElement(n) {
+		return " " + inlineChildren(n) + " "
+	}
+	return inlineChildren(n)
+}
+
+func inlineChildren(n *xhtml.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(inlineMarkdown(child))
+	}
+	return b.String()
+}
+
+func wrapInline(marker string, value string) string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return value
+	}
+	out := marker + trimmed + marker
+	if strings.TrimLeft(value, " \t\n") != value {
+		out = " " + out
+	}
+	if strings.TrimRight(value, " \t\n") != value {
+		out += " "
+	}
+	return out
+}
+
+func isMarkdownLinkTarget(href string) bool {
+	lower := strings.ToLower(href)
+	return strings.HasPrefix(lower, "http://") ||
+		strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "mailto:")
+}
+
+func attrValue(n *xhtml.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func isSkippedElement(n *xhtml.Node) bool {
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Template, atom.Svg, atom.Button:
+		return true
+	}
+	return false
+}
+
+func isBlockElement(n *xhtml.Node) bool {
+	switch n.DataAtom {
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer,
+		atom.Main, atom.Aside, atom.Blockquote, atom.Pre, atom.Table, atom.Thead,
+		atom.Tbody, atom.Tr, atom.Td, atom.Th, atom.Ul, atom.Ol, atom.Li,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Hr, atom.Br,
+		atom.Dl, atom.Dt, atom.Dd:
+		return true
+	}
+	return false
+}
+
+func collapseSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func collapseMarkdownLines(value string) string {
+	lines := strings.Split(value, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = collapseSpaces(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
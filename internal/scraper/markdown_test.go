@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescriptionFromHTML(t *testing.T) {
+	raw := `<h2>Your tasks</h2>
+<p>Join our <strong>platform</strong> team.</p>
+<ul>
+  <li>Build APIs</li>
+  <li>Operate services
+    <ul><li>On-call rotation</li></ul>
+  </li>
+</ul>
+<ol><li>Apply <a href="https://example.com/apply">here</a></li></ol>
+<script>track()</script>`
+
+	desc := descriptionFromHTML(raw)
+
+	wantMarkdown := strings.Join([]string{
+		"## Your tasks",
+		"",
+		"Join our **platform** team.",
+		"",
+		"- Build APIs",
+		"- Operate services",
+		"  - On-call rotation",
+		"",
+		"1. Apply [here](https://example.com/apply)",
+	}, "\n")
+	if desc.Markdown != wantMarkdown {
+		t.Fatalf("unexpected markdown:\n%s\nwant:\n%s", desc.Markdown, wantMarkdown)
+	}
+
+	wantText := "Your tasks Join our platform team. Build APIs Operate services On-call rotation Apply here"
+	if desc.Text != wantText {
+		t.Fatalf("unexpected text: %q", desc.Text)
+	}
+	if desc.HTML == "" || strings.Contains(desc.Markdown, "track()") {
+		t.Fatalf("unexpected html/script handling: %+v", desc)
+	}
+}
+
+func TestDescriptionFromHTML_UnescapesJSONLDMarkup(t *testing.T) {
+	desc := descriptionFromHTML("&lt;p&gt;Build&lt;br&gt;APIs&lt;/p&gt;")
+	if desc.Markdown != "Build\nAPIs" {
+		t.Fatalf("unexpected markdown: %q", desc.Markdown)
+	}
+	if desc.Text != "Build APIs" {
+		t.Fatalf("unexpected text: %q", desc.Text)
+	}
+}
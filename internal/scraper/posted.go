@@ -0,0 +1,211 @@
+package scraper
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+const oneDay = 24 * time.Hour
+
+type postedUnit struct {
+	duration  time.Duration
+	precision string
+}
+
+var (
+	postedMinute = postedUnit{duration: time.Minute, precision: models.PostedAtExact}
+	postedHour   = postedUnit{duration: time.Hour, precision: models.PostedAtExact}
+	postedDay    = postedUnit{duration: oneDay, precision: models.PostedAtDay}
+	postedWeek   = postedUnit{duration: 7 * oneDay, precision: models.PostedAtApproximate}
+	postedMonth  = postedUnit{duration: 30 * oneDay, precision: models.PostedAtApproximate}
+	postedYear   = postedUnit{duration: 365 * oneDay, precision: models.PostedAtApproximate}
+)
+
+// postedUnits maps English, German, French and Spanish unit words to durations.
+var postedUnits = map[string]postedUnit{
+	"m": postedMinute, "min": postedMinute, "mins": postedMinute, "minute": postedMinute,
+	"minutes": postedMinute, "minuten": postedMinute, "minuto": postedMinute, "minutos": postedMinute,
+
+	"h": postedHour, "hr": postedHour, "hrs": postedHour, "hour": postedHour, "hours": postedHour,
+	"std": postedHour, "stunde": postedHour, "stunden": postedHour, "heure": postedHour,
+	"heures": postedHour, "hora": postedHour, "horas": postedHour,
+
+	"d": postedDay, "day": postedDay, "days": postedDay, "tag": postedDay, "tage": postedDay,
+	"tagen": postedDay, "jour": postedDay, "jours": postedDay, "día": postedDay, "días": postedDay,
+	"dia": postedDay, "dias": postedDay,
+
+	"w": postedWeek, "wk": postedWeek, "wks": postedWeek, "week": postedWeek, "weeks": postedWeek,
+	"woche": postedWeek, "wochen": postedWeek, "semaine": postedWeek, "semaines": postedWeek,
+	"semana": postedWeek, "semanas": postedWeek,
+
+	"mo": postedMonth, "month": postedMonth, "months": postedMonth, "monat": postedMonth,
+	"monate": postedMonth, "monaten": postedMonth, "mois": postedMonth, "mes": postedMonth,
+	"meses": postedMonth,
+
+	"y": postedYear, "yr": postedYear, "yrs": postedYear, "year": postedYear, "years": postedYear,
+	"jahr": postedYear, "jahre": postedYear, "jahren": postedYear, "année": postedYear, "années": postedYear, "año": postedYear, "años": postedYear,
+}
+
+// postedNumberWords covers the article-style amounts used in phrases like "an hour ago".
+var postedNumberWords = map[string]int{
+	"a": 1, "an": 1, "one": 1, "ein": 1, "eine": 1, "einem": 1, "einer": 1,
+	"un": 1, "une": 1, "uno": 1, "una": 1,
+	"two": 2, "zwei": 2, "deux": 2, "dos": 2,
+	"three": 3, "drei": 3, "trois": 3, "tres": 3,
+}
+
+// postedFrenchYearUnits are the French year units. "an" is also the English
+// article ("an hour ago"), so they only count in "il y a" phrasing.
+var postedFrenchYearUnits = []string{"an", "ans"}
+
+var (
+	postedTodayPhrases = []string{
+		"just posted", "just now", "today", "heute", "gerade eben", "soeben",
+		"aujourd'hui", "aujourd’hui", "à l'instant", "hoy", "ahora",
+	}
+	postedDayBeforeYesterdayPhrases = []string{"vorgestern", "avant-hier", "anteayer"}
+	postedYesterdayPhrases          = []string{"yesterday", "gestern", "ayer"}
+	// French "hier" is also a common German word ("Jetzt hier bewerben"), so it
+	// only counts on its own or in French phrasing.
+	postedYesterdayFrenchPhrases = []string{
+		"publié hier", "publiée hier", "posté hier", "postée hier", "mis en ligne hier",
+		"mise en ligne hier", "depuis hier", "hier à",
+	}
+)
+
+// applyPostedAt parses job.PostedAtRaw relative to now and records the precision.
+func applyPostedAt(job *models.Job, now time.Time) {
+	if job == nil || strings.TrimSpace(job.PostedAtRaw) == "" {
+		return
+	}
+	ts, precision, err := parsePostedDate(job.PostedAtRaw, now)
+	if err != nil {
+		return
+	}
+	job.PostedAt = ts
+	job.PostedAtPrecision = precision
+}
+
+// parsePostedDate accepts absolute timestamps and relative phrases such as
+// "3 days ago", "vor 2 Tagen", "il y a 1 semaine", "hace 5 horas" or
+// "Posted 30+ Days Ago". Relative values are resolved against now.
+func parsePostedDate(value string, now time.Time) (time.Time, string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, "", fmt.Errorf("empty")
+	}
+
+	if ts, err := parsePostedAt(value); err == nil {
+		if !strings.Contains(value, ":") {
+			return ts, models.PostedAtDay, nil
+		}
+		return ts, models.PostedAtExact, nil
+	}
+
+	return parseRelativePostedAt(value, now)
+}
+
+func parseRelativePostedAt(value string, now time.Time) (time.Time, string, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(value), " "))
+
+	if containsAnyWord(normalized, postedDayBeforeYesterdayPhrases) {
+		return now.Add(-2 * oneDay), models.PostedAtDay, nil
+	}
+	if containsAnyWord(normalized, postedTodayPhrases) {
+		return now, models.PostedAtDay, nil
+	}
+	if containsAnyWord(normalized, postedYesterdayPhrases) || normalized == "hier" ||
+		containsAnyWord(normalized, postedYesterdayFrenchPhrases) {
+		return now.Add(-oneDay), models.PostedAtDay, nil
+	}
+
+	tokens := strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+'
+	})
+	for i, token := range tokens {
+		amount, rest, plus, ok := splitPostedAmount(token)
+		if !ok {
+			continue
+		}
+		unitWord := rest
+		if unitWord == "" {
+			if i+1 >= len(tokens) {
+				continue
+			}
+			unitWord = tokens[i+1]
+			if unitWord == "+" && i+2 < len(tokens) {
+				plus = true
+				unitWord = tokens[i+2]
+			}
+		}
+		unit, ok := postedUnits[unitWord]
+		if !ok && slices.Contains(postedFrenchYearUnits, unitWord) && containsAnyWord(normalized, []string{"il y a"}) {
+			unit, ok = postedYear, true
+		}
+		if !ok {
+			continue
+		}
+		precision := unit.precision
+		if plus {
+			precision = models.PostedAtApproximate
+		}
+		return now.Add(-time.Duration(amount) * unit.duration), precision, nil
+	}
+
+	return time.Time{}, "", fmt.Errorf("unsupported time format: %s", value)
+}
+
+// splitPostedAmount splits tokens like "3", "30+", "3d" or "an" into an amount,
+// the unit remainder and whether the amount was open-ended.
+func splitPostedAmount(token string) (int, string, bool, bool) {
+	if amount, ok := postedNumberWords[token]; ok {
+		return amount, "", false, true
+	}
+
+	end := 0
+	for end < len(token) && token[end] >= '0' && token[end] <= '9' {
+		end++
+	}
+	if end == 0 {
+		return 0, "", false, false
+	}
+	amount, err := strconv.Atoi(token[:end])
+	if err != nil {
+		return 0, "", false, false
+	}
+	rest := token[end:]
+	plus := strings.HasPrefix(rest, "+")
+	return amount, strings.TrimPrefix(rest, "+"), plus, true
+}
+
+func containsAnyWord(value string, phrases []string) bool {
+	for _, phrase := range phrases {
+		idx := strings.Index(value, phrase)
+		for idx >= 0 {
+			end := idx + len(phrase)
+			if isWordBoundary(value, idx-1) && isWordBoundary(value, end) {
+				return true
+			}
+			next := strings.Index(value[idx+1:], phrase)
+			if next < 0 {
+				break
+			}
+			idx += next + 1
+		}
+	}
+	return false
+}
+
+func isWordBoundary(value string, idx int) bool {
+	if idx < 0 || idx >= len(value) {
+		return true
+	}
+	r := rune(value[idx])
+	return r < 0x80 && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,76 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestParsePostedDate(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value     string
+		want      time.Time
+		precision string
+	}{
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), models.PostedAtDay},
+		{"2024-03-01T08:30:00Z", time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), models.PostedAtExact},
+		{"05.03.2024", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), models.PostedAtDay},
+		{"3 days ago", now.Add(-3 * oneDay), models.PostedAtDay},
+		{"Posted 3 hours ago", now.Add(-3 * time.Hour), models.PostedAtExact},
+		{"an hour ago", now.Add(-time.Hour), models.PostedAtExact},
+		{"1 week ago", now.Add(-7 * oneDay), models.PostedAtApproximate},
+		{"Posted 30+ Days Ago", now.Add(-30 * oneDay), models.PostedAtApproximate},
+		{"Just posted", now, models.PostedAtDay},
+		{"vor 2 Tagen", now.Add(-2 * oneDay), models.PostedAtDay},
+		{"vor einer Woche", now.Add(-7 * oneDay), models.PostedAtApproximate},
+		{"Gestern", now.Add(-oneDay), models.PostedAtDay},
+		{"Heute", now, models.PostedAtDay},
+		{"il y a 5 jours", now.Add(-5 * oneDay), models.PostedAtDay},
+		{"il y a 1 an", now.Add(-365 * oneDay), models.PostedAtApproximate},
+		{"il y a 2 ans", now.Add(-2 * 365 * oneDay), models.PostedAtApproximate},
+		{"depuis un an environ 2 h", now.Add(-2 * time.Hour), models.PostedAtExact},
+		{"Publié avant-hier", now.Add(-2 * oneDay), models.PostedAtDay},
+		{"Hier", now.Add(-oneDay), models.PostedAtDay},
+		{"Publiée hier", now.Add(-oneDay), models.PostedAtDay},
+		{"hace 4 horas", now.Add(-4 * time.Hour), models.PostedAtExact},
+		{"hace un mes", now.Add(-30 * oneDay), models.PostedAtApproximate},
+		{"Ayer", now.Add(-oneDay), models.PostedAtDay},
+		{"3d", now.Add(-3 * oneDay), models.PostedAtDay},
+	}
+
+	for _, tc := range cases {
+		got, precision, err := parsePostedDate(tc.value, now)
+		if err != nil {
+			t.Fatalf("parsePostedDate(%q) error = %v", tc.value, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Fatalf("parsePostedDate(%q) = %s, want %s", tc.value, got, tc.want)
+		}
+		if precision != tc.precision {
+			t.Fatalf("parsePostedDate(%q) precision = %q, want %q", tc.value, precision, tc.precision)
+		}
+	}
+}
+
+func TestParsePostedDate_RejectsUnknownPhrases(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	for _, value := range []string{"", "Top-Job", "Schnelle Bewerbung", "Hybrid", "Jetzt hier bewerben", "Hier arbeiten"} {
+		if _, _, err := parsePostedDate(value, now); err == nil {
+			t.Fatalf("parsePostedDate(%q) error = nil, want error", value)
+		}
+	}
+}
+
+func TestApplyPostedAt(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	job := models.Job{PostedAtRaw: "vor 3 Stunden"}
+	applyPostedAt(&job, now)
+	if !job.PostedAt.Equal(now.Add(-3 * time.Hour)) {
+		t.Fatalf("unexpected PostedAt: %s", job.PostedAt)
+	}
+	if job.PostedAtPrecision != models.PostedAtExact {
+		t.Fatalf("unexpected precision: %q", job.PostedAtPrecision)
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/jimezsa/jobcli/internal/models"
 	"github.com/jimezsa/jobcli/internal/network"
+	"github.com/jimezsa/jobcli/internal/normalize"
 )
 
 const stepstonePageSize = 25
@@ -71,7 +73,7 @@ func (s *Stepstone) Search(ctx context.Context, params models.SearchParams) ([]m
 				continue
 			}
 			if job.Description == "" {
-				job.Description = s.fetchStepstoneDescription(ctx, job.URL)
+				applyDescription(&job, s.fetchStepstoneDescription(ctx, job.URL))
 			}
 			if job.Description == "" {
 				job.Description = job.Snippet
@@ -149,6 +151,7 @@ func parseStepstoneJobs(doc *goquery.Document) []models.Job {
 func parseStepstoneJobCards(doc *goquery.Document) []models.Job {
 	var jobs []models.Job
 	seen := map[string]struct{}{}
+	now := time.Now()
 
 	doc.Find("a[href*='stellenangebote--']").Each(func(_ int, s *goquery.Selection) {
 		href := strings.TrimSpace(s.AttrOr("href", ""))
@@ -169,18 +172,22 @@ func parseStepstoneJobCards(doc *goquery.Document) []models.Job {
 		}
 
 		card := stepstoneCardForAnchor(s)
-		company, location, snippet, posted, remote := stepstoneParseCard(card, title)
-
-		jobs = append(jobs, models.Job{
-			Site:        SiteStepstone,
-			Title:       title,
-			Company:     company,
-			Location:    location,
-			URL:         link,
-			Snippet:     snippet,
-			PostedAtRaw: posted,
-			Remote:      remote || isRemote(location, snippet),
-		})
+		company, location, snippet, posted, arrangement := stepstoneParseCard(card, title)
+
+		job := models.Job{
+			Site:            SiteStepstone,
+			Title:           title,
+			Company:         company,
+			Location:        location,
+			URL:             link,
+			Snippet:         snippet,
+			PostedAtRaw:     posted,
+			WorkArrangement: arrangement,
+		}
+		applyPostedAt(&job, now)
+		applyWorkArrangement(&job)
+		applyJobIdentity(&job)
+		jobs = append(jobs, job)
 		seen[link] = struct{}{}
 	})
 
@@ -206,18 +213,20 @@ func stepstoneCardForAnchor(s *goquery.Selection) *goquery.Selection {
 	return s.Parent()
 }
 
-func stepstoneParseCard(card *goquery.Selection, title string) (string, string, string, string, bool) {
+// stepstoneParseCard returns the company, location, snippet, posted text and the
+// work arrangement read from the card's home-office badge.
+func stepstoneParseCard(card *goquery.Selection, title string) (string, string, string, string, string) {
 	if card == nil || card.Length() == 0 {
-		return "", "", "", "", false
+		return "", "", "", "", ""
 	}
 
 	posted := stepstonePostedText(card)
 	lines := stepstoneCardLines(card, title)
 
-	remote := false
+	arrangement := ""
 	for _, line := range lines {
 		if stepstoneIsRemoteLine(line) {
-			remote = true
+			arrangement = normalize.WorkArrangementFromText(line)
 			break
 		}
 	}
@@ -254,7 +263,7 @@ func stepstoneParseCard(card *goquery.Selection, title string) (string, string,
 		}
 	}
 
-	return company, location, snippet, posted, remote
+	return company, location, snippet, posted, arrangement
 }
 
 func stepstoneCardLines(card *goquery.Selection, title string) []string {
@@ -349,27 +358,27 @@ func stepstoneIsUsableSnippet(snippet string, company string, location string) b
 	return true
 }
 
-func (s *Stepstone) fetchStepstoneDescription(ctx context.Context, rawURL string) string {
+func (s *Stepstone) fetchStepstoneDescription(ctx context.Context, rawURL string) jobDescription {
 	rawURL = strings.TrimSpace(rawURL)
 	if rawURL == "" {
-		return ""
+		return jobDescription{}
 	}
 
 	doc, err := fetchDocument(ctx, s.client, rawURL, map[string]string{
 		"accept-language": "de-DE,de;q=0.9,en-US;q=0.8,en;q=0.7",
 	})
 	if err != nil {
-		return ""
+		return jobDescription{}
 	}
 	return parseStepstoneDescription(doc)
 }
 
-func parseStepstoneDescription(doc *goquery.Document) string {
+func parseStepstoneDescription(doc *goquery.Document) jobDescription {
 	if doc == nil {
-		return ""
+		return jobDescription{}
 	}
 
-	if description := firstText(
+	if description := firstDescription(
 		doc.Selection,
 		"div[data-at='jobad-description']",
 		"section[data-at='jobad-description']",
@@ -378,19 +387,23 @@ func parseStepstoneDescription(doc *goquery.Document) string {
 		"div[class*='listing-content-provider']",
 		"article[class*='listing-content-provider']",
 		"div[class*='job-description']",
-	); description != "" {
+	); description.Text != "" {
 		return description
 	}
 
 	jobs := parseJSONLDJobs(doc, SiteStepstone)
 	for _, job := range jobs {
 		if job.Description != "" {
-			return job.Description
+			return jobDescription{
+				Text:     job.Description,
+				Markdown: job.DescriptionMarkdown,
+				HTML:     job.DescriptionHTML,
+			}
 		}
 		if job.Snippet != "" {
-			return job.Snippet
+			return jobDescription{Text: job.Snippet, Markdown: job.Snippet}
 		}
 	}
 
-	return ""
+	return jobDescription{}
 }
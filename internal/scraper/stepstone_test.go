@@ -1,6 +1,10 @@
 package scraper
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
 
 func TestStepstoneParseCard_ExtractsTeaserSnippet(t *testing.T) {
 	html := `
@@ -14,7 +18,7 @@ func TestStepstoneParseCard_ExtractsTeaserSnippet(t *testing.T) {
 
 	doc := mustDoc(t, html)
 	card := doc.Find("article").First()
-	company, location, snippet, posted, remote := stepstoneParseCard(card, "Platform Engineer")
+	company, location, snippet, posted, arrangement := stepstoneParseCard(card, "Platform Engineer")
 
 	if company != "Example GmbH" {
 		t.Fatalf("unexpected company: %q", company)
@@ -28,8 +32,8 @@ func TestStepstoneParseCard_ExtractsTeaserSnippet(t *testing.T) {
 	if posted != "vor 2 Tagen" {
 		t.Fatalf("unexpected posted: %q", posted)
 	}
-	if remote {
-		t.Fatalf("expected remote false")
+	if arrangement != "" {
+		t.Fatalf("expected no arrangement, got %q", arrangement)
 	}
 }
 
@@ -51,11 +55,32 @@ func TestStepstoneParseCard_DoesNotUseLocationAsSnippet(t *testing.T) {
 	}
 }
 
+func TestStepstoneParseCard_ReadsHomeOfficeBadge(t *testing.T) {
+	html := `
+<article>
+  <h2>Platform Engineer</h2>
+  <div>Example GmbH</div>
+  <div>Munich, Bavaria, Germany</div>
+  <span>Teilweise Home-Office</span>
+</article>`
+
+	doc := mustDoc(t, html)
+	card := doc.Find("article").First()
+	_, location, _, _, arrangement := stepstoneParseCard(card, "Platform Engineer")
+
+	if location != "Munich, Bavaria, Germany" {
+		t.Fatalf("unexpected location: %q", location)
+	}
+	if arrangement != models.WorkArrangementHybrid {
+		t.Fatalf("arrangement = %q, want hybrid", arrangement)
+	}
+}
+
 func TestParseStepstoneDescription(t *testing.T) {
 	html := `<div data-at="jobad-description">Build APIs for enterprise integrations.</div>`
 	doc := mustDoc(t, html)
 
-	got := parseStepstoneDescription(doc)
+	got := parseStepstoneDescription(doc).Text
 	if got != "Build APIs for enterprise integrations." {
 		t.Fatalf("unexpected description: %q", got)
 	}
@@ -75,7 +100,7 @@ func TestParseStepstoneDescription_FallsBackToJSONLD(t *testing.T) {
 </script>`
 	doc := mustDoc(t, html)
 
-	got := parseStepstoneDescription(doc)
+	got := parseStepstoneDescription(doc).Text
 	if got != "Design and operate resilient services." {
 		t.Fatalf("unexpected description: %q", got)
 	}
@@ -99,15 +99,17 @@ func zipRecruiterJobFromCard(s *goquery.Selection) models.Job {
 	}
 	link = absoluteURL("https://www.ziprecruiter.com", link)
 
-	return models.Job{
+	job := models.Job{
 		Site:     SiteZipRecruiter,
 		Title:    title,
 		Company:  company,
 		Location: location,
 		URL:      link,
 		Snippet:  snippet,
-		Remote:   isRemote(location, snippet),
 	}
+	applyWorkArrangement(&job)
+	applyJobIdentity(&job)
+	return job
 }
 
 func firstText(s *goquery.Selection, selectors ...string) string {
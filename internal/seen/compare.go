@@ -2,6 +2,7 @@ package seen
 
 import (
 	"strings"
+	"time"
 
 	"github.com/jimezsa/jobcli/internal/models"
 )
@@ -29,6 +30,7 @@ type MergeStats struct {
 	InvalidSeen  int
 	InvalidInput int
 	Added        int
+	Reposted     int
 	TotalOut     int
 }
 
@@ -60,8 +62,9 @@ func Key(job models.Job) (string, bool) {
 	return "", false
 }
 
-// Diff returns unseen jobs from newJobs using existing seenJobs keys.
-func Diff(newJobs []models.Job, seenJobs []models.Job) ([]models.Job, DiffStats) {
+// Diff returns unseen jobs from newJobs using existing seenJobs keys built
+// with strategy.
+func Diff(newJobs []models.Job, seenJobs []models.Job, strategy KeyStrategy) ([]models.Job, DiffStats) {
 	stats := DiffStats{
 		TotalNew:  len(newJobs),
 		TotalSeen: len(seenJobs),
@@ -69,7 +72,7 @@ func Diff(newJobs []models.Job, seenJobs []models.Job) ([]models.Job, DiffStats)
 
 	seenKeys := make(map[string]struct{}, len(seenJobs))
 	for _, job := range seenJobs {
-		key, ok := Key(job)
+		key, ok := strategy.Key(job)
 		if !ok {
 			stats.InvalidSeen++
 			continue
@@ -83,7 +86,7 @@ func Diff(newJobs []models.Job, seenJobs []models.Job) ([]models.Job, DiffStats)
 	newKeys := make(map[string]struct{}, len(newJobs))
 	unseen := make([]models.Job, 0, len(newJobs))
 	for _, job := range newJobs {
-		key, ok := Key(job)
+		key, ok := strategy.Key(job)
 		if !ok {
 			stats.InvalidNew++
 			continue
@@ -103,18 +106,22 @@ func Diff(newJobs []models.Job, seenJobs []models.Job) ([]models.Job, DiffStats)
 }
 
 // Merge appends unique new jobs into the seen history.
-// Existing seen entries win collisions.
-func Merge(existingSeen []models.Job, inputJobs []models.Job) ([]models.Job, MergeStats) {
+// Existing seen entries win collisions but record the new observation:
+// last_seen moves to now, times_seen grows, and a newer posting date (a
+// repost) replaces the stored one. Input jobs that already carry seen times,
+// such as another history, are combined instead of counted as observations.
+// The more recently set application status wins.
+func Merge(existingSeen []models.Job, inputJobs []models.Job, strategy KeyStrategy, now time.Time) ([]models.Job, MergeStats) {
 	stats := MergeStats{
 		TotalSeen:  len(existingSeen),
 		TotalInput: len(inputJobs),
 	}
 
-	keys := make(map[string]struct{}, len(existingSeen)+len(inputJobs))
+	keys := make(map[string]int, len(existingSeen)+len(inputJobs))
 	out := make([]models.Job, 0, len(existingSeen)+len(inputJobs))
 
 	for _, job := range existingSeen {
-		key, ok := Key(job)
+		key, ok := strategy.Key(job)
 		if !ok {
 			stats.InvalidSeen++
 			out = append(out, job)
@@ -123,20 +130,27 @@ func Merge(existingSeen []models.Job, inputJobs []models.Job) ([]models.Job, Mer
 		if _, exists := keys[key]; exists {
 			continue
 		}
-		keys[key] = struct{}{}
+		keys[key] = len(out)
 		out = append(out, job)
 	}
 
 	for _, job := range inputJobs {
-		key, ok := Key(job)
+		key, ok := strategy.Key(job)
 		if !ok {
 			stats.InvalidInput++
 			continue
 		}
-		if _, exists := keys[key]; exists {
+		if index, exists := keys[key]; exists {
+			if observe(&out[index], job, now) {
+				stats.Reposted++
+			}
 			continue
 		}
-		keys[key] = struct{}{}
+		keys[key] = len(out)
+		job.Repost = false
+		if job.LastSeen.IsZero() {
+			job.FirstSeen, job.LastSeen, job.TimesSeen = now, now, 1
+		}
 		out = append(out, job)
 		stats.Added++
 	}
@@ -144,3 +158,74 @@ func Merge(existingSeen []models.Job, inputJobs []models.Job) ([]models.Job, Mer
 	stats.TotalOut = len(out)
 	return out, stats
 }
+
+// observe records input as another sighting of entry and reports whether it
+// was a repost.
+func observe(entry *models.Job, input models.Job, now time.Time) bool {
+	if input.LastSeen.IsZero() {
+		// Entries from before seen times were tracked were seen at least once.
+		entry.TimesSeen = max(entry.TimesSeen, 1) + 1
+		if now.After(entry.LastSeen) {
+			entry.LastSeen = now
+		}
+	} else {
+		if !input.FirstSeen.IsZero() && (entry.FirstSeen.IsZero() || input.FirstSeen.Before(entry.FirstSeen)) {
+			entry.FirstSeen = input.FirstSeen
+		}
+		if input.LastSeen.After(entry.LastSeen) {
+			entry.LastSeen = input.LastSeen
+		}
+		entry.TimesSeen = max(entry.TimesSeen, input.TimesSeen)
+	}
+	if input.StatusUpdatedAt.After(entry.StatusUpdatedAt) {
+		copyStatus(entry, input)
+	}
+
+	if !IsRepost(*entry, input) {
+		return false
+	}
+	entry.PostedAt = input.PostedAt
+	entry.PostedAtRaw = input.PostedAtRaw
+	entry.PostedAtPrecision = input.PostedAtPrecision
+	return true
+}
+
+// IsRepost reports whether current is a newer posting of seenJob: both have a
+// posting date that is not approximate and current's is at least a day later.
+func IsRepost(seenJob models.Job, current models.Job) bool {
+	if seenJob.PostedAt.IsZero() || current.PostedAt.IsZero() {
+		return false
+	}
+	if seenJob.PostedAtPrecision == models.PostedAtApproximate || current.PostedAtPrecision == models.PostedAtApproximate {
+		return false
+	}
+	return current.PostedAt.Sub(seenJob.PostedAt) >= 24*time.Hour
+}
+
+// MarkReposts sets Repost on jobs whose key is in seenJobs under an older
+// posting date and returns how many were marked.
+func MarkReposts(jobs []models.Job, seenJobs []models.Job, strategy KeyStrategy) int {
+	seenByKey := make(map[string]models.Job, len(seenJobs))
+	for _, job := range seenJobs {
+		key, ok := strategy.Key(job)
+		if !ok {
+			continue
+		}
+		if _, exists := seenByKey[key]; !exists {
+			seenByKey[key] = job
+		}
+	}
+
+	marked := 0
+	for i := range jobs {
+		key, ok := strategy.Key(jobs[i])
+		if !ok {
+			continue
+		}
+		if seenJob, exists := seenByKey[key]; exists && IsRepost(seenJob, jobs[i]) {
+			jobs[i].Repost = true
+			marked++
+		}
+	}
+	return marked
+}
@@ -2,6 +2,7 @@ package seen
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jimezsa/jobcli/internal/models"
 )
@@ -51,7 +52,7 @@ func TestDiff(t *testing.T) {
 		{Title: "No Company", Company: "   ", URL: ""},
 	}
 
-	unseen, stats := Diff(newJobs, seenJobs)
+	unseen, stats := Diff(newJobs, seenJobs, DefaultKeyStrategy)
 
 	if len(unseen) != 1 {
 		t.Fatalf("expected 1 unseen job, got %d", len(unseen))
@@ -91,7 +92,7 @@ func TestMergeAndIdempotency(t *testing.T) {
 		{Title: "", Company: "Broken", URL: ""},
 	}
 
-	merged, stats := Merge(existing, input)
+	merged, stats := Merge(existing, input, DefaultKeyStrategy, time.Now())
 	if len(merged) != 3 {
 		t.Fatalf("expected merged len=3, got %d", len(merged))
 	}
@@ -108,7 +109,7 @@ func TestMergeAndIdempotency(t *testing.T) {
 		t.Fatalf("TotalOut = %d, want 3", stats.TotalOut)
 	}
 
-	mergedAgain, statsAgain := Merge(merged, input)
+	mergedAgain, statsAgain := Merge(merged, input, DefaultKeyStrategy, time.Now())
 	if len(mergedAgain) != len(merged) {
 		t.Fatalf("expected idempotent merge length %d, got %d", len(merged), len(mergedAgain))
 	}
@@ -125,7 +126,7 @@ func TestDiff_UsesURLFallbackWhenTitleCompanyMissing(t *testing.T) {
 		{Title: "", Company: "", URL: "https://example.com/job-1"},
 	}
 
-	unseen, stats := Diff(newJobs, seenJobs)
+	unseen, stats := Diff(newJobs, seenJobs, DefaultKeyStrategy)
 	if len(unseen) != 0 {
 		t.Fatalf("expected no unseen jobs, got %d", len(unseen))
 	}
@@ -136,3 +137,64 @@ func TestDiff_UsesURLFallbackWhenTitleCompanyMissing(t *testing.T) {
 		t.Fatalf("InvalidSeen = %d, want 0", stats.InvalidSeen)
 	}
 }
+
+func TestMerge_TracksSeenTimes(t *testing.T) {
+	first := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	second := first.Add(7 * 24 * time.Hour)
+	posted := time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC)
+	job := models.Job{Title: "SRE", Company: "Acme", PostedAt: posted, PostedAtPrecision: models.PostedAtDay}
+
+	merged, _ := Merge(nil, []models.Job{job}, DefaultKeyStrategy, first)
+	if !merged[0].FirstSeen.Equal(first) || !merged[0].LastSeen.Equal(first) || merged[0].TimesSeen != 1 {
+		t.Fatalf("first merge = %+v, want first/last seen at %s and times_seen=1", merged[0], first)
+	}
+
+	repost := job
+	repost.PostedAt = posted.Add(10 * 24 * time.Hour)
+	merged, stats := Merge(merged, []models.Job{repost}, DefaultKeyStrategy, second)
+	if stats.Added != 0 || stats.Reposted != 1 {
+		t.Fatalf("stats = %+v, want Added=0 Reposted=1", stats)
+	}
+	got := merged[0]
+	if !got.FirstSeen.Equal(first) || !got.LastSeen.Equal(second) || got.TimesSeen != 2 {
+		t.Fatalf("second merge = %+v, want first=%s last=%s times_seen=2", got, first, second)
+	}
+	if !got.PostedAt.Equal(repost.PostedAt) || got.Repost {
+		t.Fatalf("second merge PostedAt = %s, Repost = %v; want newer posting date stored without the flag", got.PostedAt, got.Repost)
+	}
+}
+
+func TestMerge_CombinesTrackedInput(t *testing.T) {
+	early := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	existing := []models.Job{{Title: "SRE", Company: "Acme", FirstSeen: late, LastSeen: late, TimesSeen: 2}}
+	input := []models.Job{{Title: "SRE", Company: "Acme", FirstSeen: early, LastSeen: early, TimesSeen: 5}}
+
+	merged, _ := Merge(existing, input, DefaultKeyStrategy, time.Now())
+	if !merged[0].FirstSeen.Equal(early) || !merged[0].LastSeen.Equal(late) || merged[0].TimesSeen != 5 {
+		t.Fatalf("Merge() = %+v, want first=%s last=%s times_seen=5", merged[0], early, late)
+	}
+}
+
+func TestMarkReposts(t *testing.T) {
+	posted := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	seenJobs := []models.Job{
+		{Title: "SRE", Company: "Acme", PostedAt: posted},
+		{Title: "Data Engineer", Company: "Beta", PostedAt: posted},
+	}
+	jobs := []models.Job{
+		{Title: "SRE", Company: "Acme", PostedAt: posted.Add(14 * 24 * time.Hour)},
+		{Title: "Data Engineer", Company: "Beta", PostedAt: posted.Add(2 * time.Hour)},
+		{Title: "QA", Company: "Gamma", PostedAt: posted.Add(30 * 24 * time.Hour)},
+		{Title: "Data Engineer", Company: "Beta", PostedAt: posted.Add(20 * 24 * time.Hour), PostedAtPrecision: models.PostedAtApproximate},
+	}
+
+	if got := MarkReposts(jobs, seenJobs, DefaultKeyStrategy); got != 1 {
+		t.Fatalf("MarkReposts() = %d, want 1", got)
+	}
+	for i, want := range []bool{true, false, false, false} {
+		if jobs[i].Repost != want {
+			t.Fatalf("jobs[%d].Repost = %v, want %v", i, jobs[i].Repost, want)
+		}
+	}
+}
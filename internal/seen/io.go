@@ -1,37 +1,157 @@
 package seen
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/jimezsa/jobcli/internal/fsutil"
 	"github.com/jimezsa/jobcli/internal/models"
 )
 
-// ReadJobs reads a JSON array of jobs from path.
+// History is a seen file: the jobs plus the key strategy they were recorded
+// with. Files using the default strategy are stored as a plain JSON array;
+// other strategies use {"key_strategy": ..., "jobs": [...]}.
+type History struct {
+	KeyStrategy KeyStrategy  `json:"key_strategy,omitempty"`
+	Jobs        []models.Job `json:"jobs"`
+}
+
+// ReadJobs reads jobs from path, accepting both a JSON array and a history object.
 func ReadJobs(path string) ([]models.Job, error) {
+	history, err := ReadHistory(path)
+	if err != nil {
+		return nil, err
+	}
+	return history.Jobs, nil
+}
+
+// ReadHistory reads a seen file in the array or the object form, or as JSON
+// Lines, optionally gzip-compressed; the encoding is detected from the
+// content. Paths ending in .db, .sqlite or .sqlite3 are read as a SQLite
+// store, and "-" reads standard input.
+func ReadHistory(path string) (History, error) {
 	if strings.TrimSpace(path) == "" {
-		return nil, fmt.Errorf("path is required")
+		return History{}, fmt.Errorf("path is required")
+	}
+	if IsSQLitePath(path) {
+		return readSQLiteHistory(path)
 	}
 
-	data, err := os.ReadFile(path)
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
 	if err != nil {
-		return nil, err
+		return History{}, err
 	}
-	if len(strings.TrimSpace(string(data))) == 0 {
-		return []models.Job{}, nil
+	if bytes.HasPrefix(data, gzipMagic) {
+		if data, err = gunzip(data); err != nil {
+			return History{}, err
+		}
 	}
+	return decodeHistory(data)
+}
 
-	var jobs []models.Job
-	if err := json.Unmarshal(data, &jobs); err != nil {
+var gzipMagic = []byte{0x1f, 0x8b}
+
+func gunzip(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
 		return nil, err
 	}
-	if jobs == nil {
-		return []models.Job{}, nil
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// decodeHistory accepts a JSON array of jobs, a history object, or JSON Lines:
+// one job per line, optionally preceded by a {"key_strategy": ...} line.
+func decodeHistory(data []byte) (History, error) {
+	trimmed := bytes.TrimSpace(data)
+	history := History{Jobs: []models.Job{}}
+	if len(trimmed) == 0 {
+		return history, nil
 	}
-	return jobs, nil
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &history.Jobs); err != nil {
+			return History{}, err
+		}
+		if history.Jobs == nil {
+			history.Jobs = []models.Job{}
+		}
+		return history, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	for record := 1; decoder.More(); record++ {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return History{}, fmt.Errorf("record %d: %w", record, err)
+		}
+		if record == 1 {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				return History{}, fmt.Errorf("record %d: %w", record, err)
+			}
+			_, hasJobs := fields["jobs"]
+			_, hasStrategy := fields["key_strategy"]
+			if hasJobs && !decoder.More() {
+				if err := json.Unmarshal(raw, &history); err != nil {
+					return History{}, err
+				}
+				if history.Jobs == nil {
+					history.Jobs = []models.Job{}
+				}
+				return validateStrategy(history)
+			}
+			if hasStrategy && len(fields) == 1 {
+				if err := json.Unmarshal(raw, &history); err != nil {
+					return History{}, err
+				}
+				history.Jobs = []models.Job{}
+				continue
+			}
+		}
+		var job models.Job
+		if err := json.Unmarshal(raw, &job); err != nil {
+			return History{}, fmt.Errorf("record %d: %w", record, err)
+		}
+		history.Jobs = append(history.Jobs, job)
+	}
+	return validateStrategy(history)
+}
+
+func validateStrategy(history History) (History, error) {
+	if history.KeyStrategy == "" {
+		return history, nil
+	}
+	strategy, err := ParseKeyStrategy(string(history.KeyStrategy))
+	if err != nil {
+		return History{}, err
+	}
+	history.KeyStrategy = strategy
+	return history, nil
+}
+
+// ReadHistoryAllowMissing reads a seen file and treats missing files as empty history.
+func ReadHistoryAllowMissing(path string) (History, error) {
+	history, err := ReadHistory(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return History{Jobs: []models.Job{}}, nil
+		}
+		return History{}, err
+	}
+	return history, nil
 }
 
 // ReadJobsAllowMissing reads jobs and treats missing files as empty history.
@@ -46,17 +166,108 @@ func ReadJobsAllowMissing(path string) ([]models.Job, error) {
 	return jobs, nil
 }
 
-// WriteJobs writes jobs as pretty JSON.
+// WriteJobs writes jobs as pretty JSON, or into a SQLite store for SQLite
+// paths. Paths ending in .jsonl or .ndjson get one job per line and a .gz
+// suffix gzip-compresses the file. Files are replaced atomically.
 func WriteJobs(path string, jobs []models.Job) error {
+	return WriteHistory(path, History{Jobs: jobs})
+}
+
+// UpdateHistory merges input.Jobs into the seen file at path with Merge and
+// records the strategy used: requested, else the one recorded in the file,
+// else input's when the file has no jobs yet. JSON files are read and
+// rewritten whole; a SQLite store is updated in place, touching only the rows
+// of the input jobs.
+func UpdateHistory(path string, input History, requested KeyStrategy, now time.Time) (MergeStats, error) {
 	if strings.TrimSpace(path) == "" {
-		return fmt.Errorf("path is required")
+		return MergeStats{}, fmt.Errorf("path is required")
 	}
-	if jobs == nil {
-		jobs = []models.Job{}
+	input.Jobs = historyJobs(input.Jobs)
+	if IsSQLitePath(path) {
+		return updateSQLiteHistory(path, input, requested, now)
 	}
-	data, err := json.MarshalIndent(jobs, "", "  ")
+
+	history, err := ReadHistoryAllowMissing(path)
 	if err != nil {
-		return err
+		return MergeStats{}, err
+	}
+	recorded := history.KeyStrategy
+	if recorded == "" && len(history.Jobs) == 0 {
+		recorded = input.KeyStrategy
+	}
+	strategy := ResolveKeyStrategy(requested, recorded)
+	merged, stats := Merge(history.Jobs, input.Jobs, strategy, now)
+	return stats, WriteHistory(path, History{KeyStrategy: strategy, Jobs: merged})
+}
+
+// historyJobs copies jobs without their Markdown and HTML descriptions, which
+// only exports use, so a seen file keeps one copy of each description.
+func historyJobs(jobs []models.Job) []models.Job {
+	out := make([]models.Job, len(jobs))
+	for i, job := range jobs {
+		job.DescriptionMarkdown = ""
+		job.DescriptionHTML = ""
+		out[i] = job
+	}
+	return out
+}
+
+// WriteHistory writes a seen file, recording the key strategy unless it is
+// the default: as {"key_strategy": ..., "jobs": [...]} in JSON files and as a
+// leading {"key_strategy": ...} line in JSON Lines files. SQLite stores always
+// record it.
+func WriteHistory(path string, history History) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("path is required")
+	}
+	history.Jobs = historyJobs(history.Jobs)
+	if IsSQLitePath(path) {
+		return writeSQLiteHistory(path, history)
+	}
+	recordStrategy := history.KeyStrategy != "" && history.KeyStrategy != DefaultKeyStrategy
+
+	name := strings.ToLower(path)
+	compress := strings.HasSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".gz")
+
+	var buf bytes.Buffer
+	switch {
+	case strings.HasSuffix(name, ".jsonl") || strings.HasSuffix(name, ".ndjson"):
+		encoder := json.NewEncoder(&buf)
+		if recordStrategy {
+			if err := encoder.Encode(map[string]KeyStrategy{"key_strategy": history.KeyStrategy}); err != nil {
+				return err
+			}
+		}
+		for _, job := range history.Jobs {
+			if err := encoder.Encode(job); err != nil {
+				return err
+			}
+		}
+	default:
+		var value any = history.Jobs
+		if recordStrategy {
+			value = history
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	data := buf.Bytes()
+	if compress {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write(data); err != nil {
+			return err
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		data = compressed.Bytes()
 	}
-	return os.WriteFile(path, append(data, '\n'), 0o644)
+	return fsutil.WriteFile(path, data, 0o644)
 }
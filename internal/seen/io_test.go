@@ -1,8 +1,11 @@
 package seen
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jimezsa/jobcli/internal/models"
 )
@@ -28,6 +31,29 @@ func TestReadWriteJobs(t *testing.T) {
 	}
 }
 
+func TestWriteJobsDropsDescriptionVariants(t *testing.T) {
+	for _, name := range []string{"jobs.json", "jobs.db"} {
+		path := filepath.Join(t.TempDir(), name)
+		jobs := []models.Job{{
+			Title: "SRE", Company: "Acme", URL: "https://example.com/1",
+			Description: "Run things", DescriptionMarkdown: "- Run things", DescriptionHTML: "<ul><li>Run things</li></ul>",
+		}}
+		if err := WriteJobs(path, jobs); err != nil {
+			t.Fatalf("WriteJobs(%s) error = %v", name, err)
+		}
+		got, err := ReadJobs(path)
+		if err != nil {
+			t.Fatalf("ReadJobs(%s) error = %v", name, err)
+		}
+		if len(got) != 1 || got[0].Description != "Run things" || got[0].DescriptionMarkdown != "" || got[0].DescriptionHTML != "" {
+			t.Fatalf("ReadJobs(%s) = %+v, want only the plain description", name, got)
+		}
+		if jobs[0].DescriptionMarkdown == "" {
+			t.Fatalf("WriteJobs(%s) modified its input", name)
+		}
+	}
+}
+
 func TestReadJobsAllowMissing(t *testing.T) {
 	dir := t.TempDir()
 	missing := filepath.Join(dir, "missing.json")
@@ -40,3 +66,125 @@ func TestReadJobsAllowMissing(t *testing.T) {
 		t.Fatalf("expected empty jobs for missing file, got %d", len(got))
 	}
 }
+
+func TestReadWriteHistory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "seen.json")
+	jobs := []models.Job{{Title: "SRE", Company: "Acme", URL: "https://example.com/1"}}
+
+	if err := WriteHistory(path, History{KeyStrategy: KeyURL, Jobs: jobs}); err != nil {
+		t.Fatalf("WriteHistory() error = %v", err)
+	}
+	history, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if history.KeyStrategy != KeyURL || len(history.Jobs) != 1 {
+		t.Fatalf("ReadHistory() = %+v, want url strategy with 1 job", history)
+	}
+	// Plain job readers accept the object form too.
+	got, err := ReadJobs(path)
+	if err != nil {
+		t.Fatalf("ReadJobs() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected len=1, got %d", len(got))
+	}
+
+	// The default strategy keeps the plain array form.
+	if err := WriteHistory(path, History{KeyStrategy: DefaultKeyStrategy, Jobs: jobs}); err != nil {
+		t.Fatalf("WriteHistory() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), "[") {
+		t.Fatalf("default strategy written as %q, want JSON array", data)
+	}
+	history, err = ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if history.KeyStrategy != "" {
+		t.Fatalf("KeyStrategy = %q, want empty for array files", history.KeyStrategy)
+	}
+}
+
+func TestUpdateHistoryKeepsRecordedStrategy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.json")
+	existing := []models.Job{{Site: "linkedin", Title: "SRE", Company: "Acme", URL: "https://example.com/1"}}
+	if err := WriteHistory(path, History{KeyStrategy: KeyURL, Jobs: existing}); err != nil {
+		t.Fatalf("WriteHistory() error = %v", err)
+	}
+	input := History{Jobs: []models.Job{{Site: "indeed", Title: "SRE", Company: "Acme", URL: "https://example.com/2"}}}
+	stats, err := UpdateHistory(path, input, "", time.Now())
+	if err != nil {
+		t.Fatalf("UpdateHistory() error = %v", err)
+	}
+	history, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if stats.Added != 1 || history.KeyStrategy != KeyURL || len(history.Jobs) != 2 {
+		t.Fatalf("UpdateHistory() = %+v, history %+v; want the url strategy kept and the job added", stats, history)
+	}
+}
+
+func TestReadHistoryRejectsUnknownStrategy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.json")
+	if err := os.WriteFile(path, []byte(`{"key_strategy":"title","jobs":[]}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := ReadHistory(path); err == nil {
+		t.Fatalf("ReadHistory() error = nil, want error")
+	}
+}
+
+func TestReadHistoryJSONLinesAndGzip(t *testing.T) {
+	dir := t.TempDir()
+	jobs := []models.Job{
+		{Title: "SRE", Company: "Acme", URL: "https://example.com/1"},
+		{Title: "Data Engineer", Company: "Beta", URL: "https://example.com/2"},
+	}
+
+	for _, name := range []string{"seen.jsonl", "seen.ndjson.gz", "seen.json.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := WriteHistory(path, History{KeyStrategy: KeyFuzzy, Jobs: jobs}); err != nil {
+				t.Fatalf("WriteHistory() error = %v", err)
+			}
+			history, err := ReadHistory(path)
+			if err != nil {
+				t.Fatalf("ReadHistory() error = %v", err)
+			}
+			if history.KeyStrategy != KeyFuzzy || len(history.Jobs) != 2 || history.Jobs[1].Title != "Data Engineer" {
+				t.Fatalf("ReadHistory() = %+v, want fuzzy strategy with 2 jobs", history)
+			}
+		})
+	}
+
+	// Content decides the encoding, not the file name.
+	path := filepath.Join(dir, "jobs.json")
+	lines := `{"title":"SRE","company":"Acme","url":"https://example.com/1"}
+
+{"title":"Data Engineer","company":"Beta","url":"https://example.com/2"}
+`
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	got, err := ReadJobs(path)
+	if err != nil {
+		t.Fatalf("ReadJobs() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Title != "SRE" {
+		t.Fatalf("ReadJobs() = %+v, want 2 jobs", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"title":"SRE"}`+"\n"+`{"title":`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := ReadJobs(path); err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Fatalf("ReadJobs() error = %v, want record 2 error", err)
+	}
+}
@@ -0,0 +1,118 @@
+package seen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/dedupe"
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// KeyStrategy selects how jobs are matched against seen history.
+type KeyStrategy string
+
+const (
+	// KeyTitleCompany matches on normalized title+company (the v1 key).
+	KeyTitleCompany KeyStrategy = "title-company"
+	// KeyTitleCompanyLocation also requires the same city or location, so one
+	// role advertised in several offices is tracked per office.
+	KeyTitleCompanyLocation KeyStrategy = "title-company-location"
+	// KeyURL matches on the posting URL.
+	KeyURL KeyStrategy = "url"
+	// KeyID matches on the site and its job ID.
+	KeyID KeyStrategy = "id"
+	// KeyFuzzy matches on title and company with gender markers, punctuation
+	// and legal suffixes stripped.
+	KeyFuzzy KeyStrategy = "fuzzy"
+)
+
+// DefaultKeyStrategy is used when neither the caller nor the seen file names one.
+const DefaultKeyStrategy = KeyTitleCompany
+
+// KeyStrategies lists the supported strategies in help order.
+var KeyStrategies = []KeyStrategy{KeyTitleCompany, KeyTitleCompanyLocation, KeyURL, KeyID, KeyFuzzy}
+
+// ParseKeyStrategy validates a strategy name. An empty value is returned as-is
+// so callers can fall back to the strategy recorded in the seen file.
+func ParseKeyStrategy(value string) (KeyStrategy, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return "", nil
+	}
+	for _, strategy := range KeyStrategies {
+		if string(strategy) == value {
+			return strategy, nil
+		}
+	}
+	names := make([]string, len(KeyStrategies))
+	for i, strategy := range KeyStrategies {
+		names[i] = string(strategy)
+	}
+	return "", fmt.Errorf("invalid seen key %q: expected one of %s", value, strings.Join(names, ", "))
+}
+
+// ResolveKeyStrategy picks the requested strategy, else the one recorded in
+// the seen file, else DefaultKeyStrategy.
+func ResolveKeyStrategy(requested, recorded KeyStrategy) KeyStrategy {
+	if requested != "" {
+		return requested
+	}
+	if recorded != "" {
+		return recorded
+	}
+	return DefaultKeyStrategy
+}
+
+// Key builds the comparison key for job. Strategies that need fields a job
+// lacks fall back to the URL and then to title+company.
+func (s KeyStrategy) Key(job models.Job) (string, bool) {
+	switch s {
+	case KeyTitleCompanyLocation:
+		title := Normalize(job.Title)
+		company := Normalize(job.Company)
+		if title != "" && company != "" {
+			return title + keySeparator + company + keySeparator + locationKey(job), true
+		}
+		return urlKey(job)
+	case KeyURL:
+		if key, ok := urlKey(job); ok {
+			return key, true
+		}
+		return Key(job)
+	case KeyID:
+		id := strings.TrimSpace(job.ID)
+		site := Normalize(job.Site)
+		if id != "" && site != "" {
+			return "id" + keySeparator + site + keySeparator + id, true
+		}
+		if key, ok := urlKey(job); ok {
+			return key, true
+		}
+		return Key(job)
+	case KeyFuzzy:
+		title := dedupe.NormalizeTitle(job.Title)
+		company := dedupe.NormalizeCompany(job.Company)
+		if title != "" && company != "" {
+			return "fuzzy" + keySeparator + title + keySeparator + company, true
+		}
+		return urlKey(job)
+	default:
+		return Key(job)
+	}
+}
+
+func urlKey(job models.Job) (string, bool) {
+	jobURL := Normalize(job.URL)
+	if jobURL == "" {
+		return "", false
+	}
+	return "url" + keySeparator + jobURL, true
+}
+
+// locationKey prefers the normalized city and country over the raw location.
+func locationKey(job models.Job) string {
+	if job.City != "" || job.CountryCode != "" {
+		return Normalize(job.City) + "," + Normalize(job.CountryCode)
+	}
+	return Normalize(job.Location)
+}
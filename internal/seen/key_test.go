@@ -0,0 +1,87 @@
+package seen
+
+import (
+	"testing"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestParseKeyStrategy(t *testing.T) {
+	got, err := ParseKeyStrategy(" Title-Company-Location ")
+	if err != nil || got != KeyTitleCompanyLocation {
+		t.Fatalf("ParseKeyStrategy() = %q, %v", got, err)
+	}
+	if got, err := ParseKeyStrategy(""); err != nil || got != "" {
+		t.Fatalf("ParseKeyStrategy(\"\") = %q, %v, want empty", got, err)
+	}
+	if _, err := ParseKeyStrategy("title"); err == nil {
+		t.Fatalf("ParseKeyStrategy(\"title\") error = nil, want error")
+	}
+}
+
+func TestResolveKeyStrategy(t *testing.T) {
+	if got := ResolveKeyStrategy("", ""); got != DefaultKeyStrategy {
+		t.Fatalf("ResolveKeyStrategy() = %q, want default", got)
+	}
+	if got := ResolveKeyStrategy("", KeyURL); got != KeyURL {
+		t.Fatalf("ResolveKeyStrategy() = %q, want recorded strategy", got)
+	}
+	if got := ResolveKeyStrategy(KeyID, KeyURL); got != KeyID {
+		t.Fatalf("ResolveKeyStrategy() = %q, want requested strategy", got)
+	}
+}
+
+func TestKeyStrategies(t *testing.T) {
+	job := models.Job{
+		ID:          "123",
+		Site:        "LinkedIn",
+		Title:       "Software Engineer (m/w/d)",
+		Company:     "ACME GmbH",
+		City:        "Munich",
+		CountryCode: "DE",
+		URL:         "https://example.com/jobs/123",
+	}
+	cases := map[KeyStrategy]string{
+		KeyTitleCompany:         "software engineer (m/w/d)::acme gmbh",
+		KeyTitleCompanyLocation: "software engineer (m/w/d)::acme gmbh::munich,de",
+		KeyURL:                  "url::https://example.com/jobs/123",
+		KeyID:                   "id::linkedin::123",
+		KeyFuzzy:                "fuzzy::software engineer::acme",
+	}
+	for strategy, want := range cases {
+		got, ok := strategy.Key(job)
+		if !ok || got != want {
+			t.Fatalf("%s.Key() = %q, %v, want %q", strategy, got, ok, want)
+		}
+	}
+}
+
+func TestKeyStrategies_Fallbacks(t *testing.T) {
+	job := models.Job{Site: "indeed", Title: "SRE", Company: "Acme"}
+	for _, strategy := range []KeyStrategy{KeyURL, KeyID} {
+		got, ok := strategy.Key(job)
+		if !ok || got != "sre::acme" {
+			t.Fatalf("%s.Key() = %q, %v, want title+company fallback", strategy, got, ok)
+		}
+	}
+
+	job = models.Job{URL: "https://example.com/1"}
+	for _, strategy := range []KeyStrategy{KeyTitleCompanyLocation, KeyFuzzy} {
+		got, ok := strategy.Key(job)
+		if !ok || got != "url::https://example.com/1" {
+			t.Fatalf("%s.Key() = %q, %v, want url fallback", strategy, got, ok)
+		}
+	}
+}
+
+func TestDiff_FuzzyKeyMatchesAcrossSites(t *testing.T) {
+	seenJobs := []models.Job{{Site: "stepstone", Title: "Data Engineer (m/w/d)", Company: "ACME GmbH"}}
+	newJobs := []models.Job{{Site: "linkedin", Title: "Data Engineer m/f/d", Company: "Acme"}}
+
+	if unseen, _ := Diff(newJobs, seenJobs, KeyTitleCompany); len(unseen) != 1 {
+		t.Fatalf("title-company unseen = %d, want 1", len(unseen))
+	}
+	if unseen, _ := Diff(newJobs, seenJobs, KeyFuzzy); len(unseen) != 0 {
+		t.Fatalf("fuzzy unseen = %d, want 0", len(unseen))
+	}
+}
@@ -0,0 +1,104 @@
+package seen
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// PruneStats captures stats for seen history pruning.
+type PruneStats struct {
+	TotalSeen int
+	Unknown   int
+	Tracked   int
+	Pruned    int
+	TotalOut  int
+}
+
+// Prune drops jobs last observed before cutoff. Jobs without seen times and
+// jobs with an application status are kept, so ignored jobs never resurface.
+func Prune(seenJobs []models.Job, cutoff time.Time) ([]models.Job, PruneStats) {
+	stats := PruneStats{TotalSeen: len(seenJobs)}
+	kept := make([]models.Job, 0, len(seenJobs))
+	for _, job := range seenJobs {
+		last := lastObserved(job)
+		switch {
+		case last.IsZero():
+			stats.Unknown++
+		case !last.Before(cutoff):
+		case job.Status != "":
+			stats.Tracked++
+		default:
+			stats.Pruned++
+			continue
+		}
+		kept = append(kept, job)
+	}
+	stats.TotalOut = len(kept)
+	return kept, stats
+}
+
+// CompactStats captures stats for seen history compaction.
+type CompactStats struct {
+	TotalSeen  int
+	Duplicates int
+	Compacted  int
+	TotalOut   int
+}
+
+// Compact drops jobs repeating an earlier key, keeping the first like Merge,
+// and strips each job down to the fields seen history relies on: every field
+// a key strategy reads, posting dates for repost detection, seen times and
+// application status. Descriptions, snippets, salaries and other details are
+// dropped.
+func Compact(seenJobs []models.Job, strategy KeyStrategy) ([]models.Job, CompactStats) {
+	stats := CompactStats{TotalSeen: len(seenJobs)}
+	keys := make(map[string]struct{}, len(seenJobs))
+	compacted := make([]models.Job, 0, len(seenJobs))
+	for _, job := range seenJobs {
+		if key, ok := strategy.Key(job); ok {
+			if _, exists := keys[key]; exists {
+				stats.Duplicates++
+				continue
+			}
+			keys[key] = struct{}{}
+		}
+
+		slim := models.Job{
+			ID:                job.ID,
+			Site:              job.Site,
+			Title:             job.Title,
+			Company:           job.Company,
+			Location:          job.Location,
+			City:              job.City,
+			CountryCode:       job.CountryCode,
+			URL:               job.URL,
+			PostedAt:          job.PostedAt,
+			PostedAtRaw:       job.PostedAtRaw,
+			PostedAtPrecision: job.PostedAtPrecision,
+			Sources:           job.Sources,
+			FirstSeen:         job.FirstSeen,
+			LastSeen:          job.LastSeen,
+			TimesSeen:         job.TimesSeen,
+			Status:            job.Status,
+			StatusNote:        job.StatusNote,
+			StatusUpdatedAt:   job.StatusUpdatedAt,
+		}
+		if !reflect.DeepEqual(slim, job) {
+			stats.Compacted++
+		}
+		compacted = append(compacted, slim)
+	}
+	stats.TotalOut = len(compacted)
+	return compacted, stats
+}
+
+// lastObserved returns when a seen job was last observed, falling back to
+// its first sighting; zero when neither is known.
+func lastObserved(job models.Job) time.Time {
+	if !job.LastSeen.IsZero() {
+		return job.LastSeen
+	}
+	return job.FirstSeen
+}
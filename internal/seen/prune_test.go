@@ -0,0 +1,54 @@
+package seen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{Title: "Old", Company: "Acme", FirstSeen: now.AddDate(0, -6, 0), LastSeen: now.AddDate(0, -4, 0)},
+		{Title: "Fresh", Company: "Acme", FirstSeen: now.AddDate(0, -6, 0), LastSeen: now.AddDate(0, 0, -2)},
+		{Title: "Ignored", Company: "Acme", LastSeen: now.AddDate(-1, 0, 0), Status: models.StatusIgnored},
+		{Title: "Legacy", Company: "Acme"},
+	}
+
+	kept, stats := Prune(jobs, now.AddDate(0, 0, -90))
+	if len(kept) != 3 || kept[0].Title != "Fresh" || kept[1].Title != "Ignored" || kept[2].Title != "Legacy" {
+		t.Fatalf("Prune() = %+v, want Fresh, Ignored and Legacy", kept)
+	}
+	if stats.TotalSeen != 4 || stats.Pruned != 1 || stats.Tracked != 1 || stats.Unknown != 1 || stats.TotalOut != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	seenAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{
+			Site: "linkedin", ID: "1", Title: "SRE", Company: "Acme", City: "Berlin", URL: "https://example.com/1",
+			Description: "Long description", Snippet: "Short", Salary: "60k", SalaryMin: 60000,
+			FirstSeen: seenAt, LastSeen: seenAt, TimesSeen: 3, Status: models.StatusApplied,
+		},
+		{Site: "indeed", Title: "sre", Company: "acme", URL: "https://example.com/2"},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", URL: "https://example.com/3"},
+	}
+
+	compacted, stats := Compact(jobs, DefaultKeyStrategy)
+	if stats.TotalSeen != 3 || stats.Duplicates != 1 || stats.Compacted != 1 || stats.TotalOut != 2 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	job := compacted[0]
+	if job.Description != "" || job.Snippet != "" || job.Salary != "" || job.SalaryMin != 0 {
+		t.Fatalf("Compact() kept details: %+v", job)
+	}
+	if job.ID != "1" || job.City != "Berlin" || job.TimesSeen != 3 || !job.FirstSeen.Equal(seenAt) || job.Status != models.StatusApplied {
+		t.Fatalf("Compact() dropped key fields: %+v", job)
+	}
+	if compacted[1].Title != "Data Engineer" {
+		t.Fatalf("compacted[1] = %+v, want Data Engineer", compacted[1])
+	}
+}
@@ -0,0 +1,439 @@
+package seen
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema stores each job as JSON in data, next to its comparison key and
+// a few columns for querying the store directly. Jobs without a valid key keep
+// a NULL key so they are preserved like invalid entries in a JSON history.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS meta (
+	name  TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS jobs (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	key        TEXT UNIQUE,
+	site       TEXT NOT NULL DEFAULT '',
+	job_id     TEXT NOT NULL DEFAULT '',
+	url        TEXT NOT NULL DEFAULT '',
+	title      TEXT NOT NULL DEFAULT '',
+	company    TEXT NOT NULL DEFAULT '',
+	first_seen TEXT NOT NULL,
+	last_seen  TEXT NOT NULL,
+	status     TEXT NOT NULL DEFAULT '',
+	data       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS jobs_last_seen ON jobs(last_seen);
+`
+
+const metaKeyStrategy = "key_strategy"
+
+// sqliteUpsert stores one job by key, leaving rows whose data is unchanged alone.
+const sqliteUpsert = `
+INSERT INTO jobs (key, site, job_id, url, title, company, first_seen, last_seen, status, data)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET
+	site = excluded.site,
+	job_id = excluded.job_id,
+	url = excluded.url,
+	title = excluded.title,
+	company = excluded.company,
+	first_seen = MIN(jobs.first_seen, excluded.first_seen),
+	last_seen = excluded.last_seen,
+	status = excluded.status,
+	data = excluded.data
+WHERE jobs.data <> excluded.data`
+
+// IsSQLitePath reports whether path names a SQLite store (.db, .sqlite or
+// .sqlite3) rather than a JSON file.
+func IsSQLitePath(path string) bool {
+	switch strings.ToLower(filepath.Ext(strings.TrimSpace(path))) {
+	case ".db", ".sqlite", ".sqlite3":
+		return true
+	}
+	return false
+}
+
+func openSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// A single connection keeps transactions and pragmas on the same handle.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout = 5000"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// readSQLiteHistory loads every stored job in insertion order. A missing
+// store is reported as os.ErrNotExist instead of being created.
+func readSQLiteHistory(path string) (History, error) {
+	if _, err := os.Stat(path); err != nil {
+		return History{}, err
+	}
+	db, err := openSQLite(path)
+	if err != nil {
+		return History{}, err
+	}
+	defer db.Close()
+
+	strategy, err := sqliteKeyStrategy(db)
+	if err != nil {
+		return History{}, err
+	}
+
+	rows, err := db.Query("SELECT data, first_seen, last_seen FROM jobs ORDER BY id")
+	if err != nil {
+		return History{}, err
+	}
+	defer rows.Close()
+
+	history := History{KeyStrategy: strategy, Jobs: []models.Job{}}
+	for rows.Next() {
+		var data, firstSeen, lastSeen string
+		if err := rows.Scan(&data, &firstSeen, &lastSeen); err != nil {
+			return History{}, err
+		}
+		var job models.Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			return History{}, fmt.Errorf("decode stored job: %w", err)
+		}
+		// Rows stored before seen times were part of the job keep them only in columns.
+		if job.FirstSeen.IsZero() {
+			job.FirstSeen, _ = time.Parse(time.RFC3339, firstSeen)
+		}
+		if job.LastSeen.IsZero() {
+			job.LastSeen, _ = time.Parse(time.RFC3339, lastSeen)
+		}
+		history.Jobs = append(history.Jobs, job)
+	}
+	return history, rows.Err()
+}
+
+// writeSQLiteHistory makes the store hold exactly history.Jobs, for commands
+// that rewrite the whole history (prune, compact, track set). Rows are
+// upserted by key, so unchanged jobs are not rewritten; first_seen/last_seen
+// come from the job, or the write time for jobs without seen times. Rows whose
+// key is gone are deleted. Runs that only add observations use
+// updateSQLiteHistory instead.
+func writeSQLiteHistory(path string, history History) error {
+	strategy := ResolveKeyStrategy(history.KeyStrategy, "")
+
+	db, err := openSQLite(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	recorded, err := sqliteKeyStrategy(tx)
+	if err != nil {
+		return err
+	}
+	if ResolveKeyStrategy(recorded, "") != strategy {
+		if err := rekeySQLite(tx, strategy); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM jobs WHERE key IS NULL"); err != nil {
+		return err
+	}
+
+	upsert, err := tx.Prepare(sqliteUpsert)
+	if err != nil {
+		return err
+	}
+	defer upsert.Close()
+
+	now := time.Now()
+	kept := make(map[string]struct{}, len(history.Jobs))
+	for _, job := range history.Jobs {
+		data, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		var key any
+		if value, ok := strategy.Key(job); ok {
+			key = value
+			kept[value] = struct{}{}
+		}
+		firstSeen, lastSeen := sqliteTime(job.FirstSeen, now), sqliteTime(job.LastSeen, now)
+		if _, err := upsert.Exec(key, job.Site, job.ID, job.URL, job.Title, job.Company, firstSeen, lastSeen, job.Status, string(data)); err != nil {
+			return err
+		}
+	}
+
+	stale, err := sqliteKeys(tx)
+	if err != nil {
+		return err
+	}
+	for _, key := range stale {
+		if _, ok := kept[key]; ok {
+			continue
+		}
+		if _, err := tx.Exec("DELETE FROM jobs WHERE key = ?", key); err != nil {
+			return err
+		}
+	}
+
+	if err := setSQLiteKeyStrategy(tx, strategy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateSQLiteHistory is UpdateHistory for a SQLite store. Only the rows for
+// the keys of input.Jobs are read and written, so a run costs its own size
+// rather than the size of the store. A store recorded with another strategy
+// is rekeyed first; that is a one-off migration of every row.
+func updateSQLiteHistory(path string, input History, requested KeyStrategy, now time.Time) (MergeStats, error) {
+	stats := MergeStats{TotalInput: len(input.Jobs)}
+
+	db, err := openSQLite(path)
+	if err != nil {
+		return stats, err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return stats, err
+	}
+	defer tx.Rollback()
+
+	recorded, err := sqliteKeyStrategy(tx)
+	if err != nil {
+		return stats, err
+	}
+	if err := tx.QueryRow("SELECT COUNT(*), COUNT(*) - COUNT(key) FROM jobs").Scan(&stats.TotalSeen, &stats.InvalidSeen); err != nil {
+		return stats, err
+	}
+	if recorded == "" && stats.TotalSeen == 0 {
+		recorded = input.KeyStrategy
+	}
+	strategy := ResolveKeyStrategy(requested, recorded)
+	if stats.TotalSeen > 0 && ResolveKeyStrategy(recorded, "") != strategy {
+		if err := rekeySQLite(tx, strategy); err != nil {
+			return stats, err
+		}
+	}
+
+	upsert, err := tx.Prepare(sqliteUpsert)
+	if err != nil {
+		return stats, err
+	}
+	defer upsert.Close()
+
+	for _, job := range input.Jobs {
+		key, ok := strategy.Key(job)
+		if !ok {
+			stats.InvalidInput++
+			continue
+		}
+		entry, found, err := sqliteJob(tx, key)
+		if err != nil {
+			return stats, err
+		}
+		if found {
+			if observe(&entry, job, now) {
+				stats.Reposted++
+			}
+			job = entry
+		} else {
+			job.Repost = false
+			if job.LastSeen.IsZero() {
+				job.FirstSeen, job.LastSeen, job.TimesSeen = now, now, 1
+			}
+			stats.Added++
+		}
+		data, err := json.Marshal(job)
+		if err != nil {
+			return stats, err
+		}
+		firstSeen, lastSeen := sqliteTime(job.FirstSeen, now), sqliteTime(job.LastSeen, now)
+		if _, err := upsert.Exec(key, job.Site, job.ID, job.URL, job.Title, job.Company, firstSeen, lastSeen, job.Status, string(data)); err != nil {
+			return stats, err
+		}
+	}
+
+	if err := tx.QueryRow("SELECT COUNT(*) FROM jobs").Scan(&stats.TotalOut); err != nil {
+		return stats, err
+	}
+	if err := setSQLiteKeyStrategy(tx, strategy); err != nil {
+		return stats, err
+	}
+	return stats, tx.Commit()
+}
+
+// sqliteJob loads the stored job with key.
+func sqliteJob(tx *sql.Tx, key string) (models.Job, bool, error) {
+	var data, firstSeen, lastSeen string
+	err := tx.QueryRow("SELECT data, first_seen, last_seen FROM jobs WHERE key = ?", key).Scan(&data, &firstSeen, &lastSeen)
+	if err == sql.ErrNoRows {
+		return models.Job{}, false, nil
+	}
+	if err != nil {
+		return models.Job{}, false, err
+	}
+	var job models.Job
+	if err := json.Unmarshal([]byte(data), &job); err != nil {
+		return models.Job{}, false, fmt.Errorf("decode stored job: %w", err)
+	}
+	if job.FirstSeen.IsZero() {
+		job.FirstSeen, _ = time.Parse(time.RFC3339, firstSeen)
+	}
+	if job.LastSeen.IsZero() {
+		job.LastSeen, _ = time.Parse(time.RFC3339, lastSeen)
+	}
+	return job, true, nil
+}
+
+func setSQLiteKeyStrategy(tx *sql.Tx, strategy KeyStrategy) error {
+	_, err := tx.Exec(
+		"INSERT INTO meta (name, value) VALUES (?, ?) ON CONFLICT(name) DO UPDATE SET value = excluded.value",
+		metaKeyStrategy, string(strategy),
+	)
+	return err
+}
+
+// rekeySQLite recomputes every key with strategy. When two rows collapse into
+// one key the older row wins, matching Merge.
+func rekeySQLite(tx *sql.Tx, strategy KeyStrategy) error {
+	rows, err := tx.Query("SELECT id, data FROM jobs ORDER BY id")
+	if err != nil {
+		return err
+	}
+	type rekeyed struct {
+		id  int64
+		key any
+	}
+	var updates []rekeyed
+	var duplicates []int64
+	used := map[string]struct{}{}
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return err
+		}
+		var job models.Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			rows.Close()
+			return fmt.Errorf("decode stored job: %w", err)
+		}
+		key, ok := strategy.Key(job)
+		if !ok {
+			updates = append(updates, rekeyed{id: id})
+			continue
+		}
+		if _, exists := used[key]; exists {
+			duplicates = append(duplicates, id)
+			continue
+		}
+		used[key] = struct{}{}
+		updates = append(updates, rekeyed{id: id, key: key})
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for _, id := range duplicates {
+		if _, err := tx.Exec("DELETE FROM jobs WHERE id = ?", id); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("UPDATE jobs SET key = NULL"); err != nil {
+		return err
+	}
+	for _, update := range updates {
+		if update.key == nil {
+			continue
+		}
+		if _, err := tx.Exec("UPDATE jobs SET key = ? WHERE id = ?", update.key, update.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sqliteTime(value time.Time, fallback time.Time) string {
+	if value.IsZero() {
+		value = fallback
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+type sqlQuerier interface {
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func sqliteKeyStrategy(q sqlQuerier) (KeyStrategy, error) {
+	var value string
+	err := q.QueryRow("SELECT value FROM meta WHERE name = ?", metaKeyStrategy).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return ParseKeyStrategy(value)
+}
+
+func sqliteKeys(q sqlQuerier) ([]string, error) {
+	rows, err := q.Query("SELECT key FROM jobs WHERE key IS NOT NULL")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Vacuum rebuilds a SQLite store to release the space of deleted or shrunk
+// rows. JSON files are rewritten whole on every write and are left alone.
+func Vacuum(path string) error {
+	if !IsSQLitePath(path) {
+		return nil
+	}
+	db, err := openSQLite(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	_, err = db.Exec("VACUUM")
+	return err
+}
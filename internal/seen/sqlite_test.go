@@ -0,0 +1,171 @@
+package seen
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestSQLiteHistoryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.db")
+	jobs := []models.Job{
+		{Site: "linkedin", ID: "1", Title: "SRE", Company: "Acme", URL: "https://example.com/1"},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", URL: "https://example.com/2",
+			Sources: []models.JobSource{{Site: "indeed", URL: "https://example.com/2"}}},
+		{Site: "google"},
+	}
+
+	if err := WriteHistory(path, History{KeyStrategy: KeyURL, Jobs: jobs}); err != nil {
+		t.Fatalf("WriteHistory() error = %v", err)
+	}
+	history, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if history.KeyStrategy != KeyURL {
+		t.Fatalf("KeyStrategy = %q, want %q", history.KeyStrategy, KeyURL)
+	}
+	if len(history.Jobs) != 3 {
+		t.Fatalf("len(history.Jobs) = %d, want 3", len(history.Jobs))
+	}
+	if history.Jobs[0].Title != "SRE" || history.Jobs[1].Sources[0].Site != "indeed" {
+		t.Fatalf("unexpected jobs read back: %+v", history.Jobs)
+	}
+
+	// Rewriting keeps the invalid entry once and drops jobs no longer listed.
+	if err := WriteHistory(path, History{KeyStrategy: KeyURL, Jobs: jobs[1:]}); err != nil {
+		t.Fatalf("WriteHistory() (2nd) error = %v", err)
+	}
+	got, err := ReadJobs(path)
+	if err != nil {
+		t.Fatalf("ReadJobs() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Title != "Data Engineer" {
+		t.Fatalf("ReadJobs() = %+v, want Data Engineer and the invalid entry", got)
+	}
+}
+
+func TestSQLiteHistoryKeepsFirstSeen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.db")
+	job := models.Job{Site: "linkedin", Title: "SRE", Company: "Acme"}
+	if err := WriteHistory(path, History{Jobs: []models.Job{job}}); err != nil {
+		t.Fatalf("WriteHistory() error = %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("UPDATE jobs SET first_seen = '2020-01-01T00:00:00Z'"); err != nil {
+		t.Fatalf("UPDATE error = %v", err)
+	}
+
+	job.URL = "https://example.com/1"
+	if err := WriteHistory(path, History{Jobs: []models.Job{job}}); err != nil {
+		t.Fatalf("WriteHistory() (2nd) error = %v", err)
+	}
+	var firstSeen, url string
+	if err := db.QueryRow("SELECT first_seen, url FROM jobs").Scan(&firstSeen, &url); err != nil {
+		t.Fatalf("SELECT error = %v", err)
+	}
+	if firstSeen != "2020-01-01T00:00:00Z" || url != job.URL {
+		t.Fatalf("first_seen = %q, url = %q; want preserved first_seen and updated url", firstSeen, url)
+	}
+}
+
+func TestSQLiteHistoryRekeysOnStrategyChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.db")
+	jobs := []models.Job{
+		{Site: "stepstone", Title: "Data Engineer (m/w/d)", Company: "ACME GmbH"},
+		{Site: "linkedin", Title: "Data Engineer m/f/d", Company: "Acme"},
+	}
+	if err := WriteHistory(path, History{Jobs: jobs}); err != nil {
+		t.Fatalf("WriteHistory() error = %v", err)
+	}
+
+	history, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	merged, _ := Merge(history.Jobs, nil, KeyFuzzy, time.Now())
+	if err := WriteHistory(path, History{KeyStrategy: KeyFuzzy, Jobs: merged}); err != nil {
+		t.Fatalf("WriteHistory() (fuzzy) error = %v", err)
+	}
+
+	history, err = ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if history.KeyStrategy != KeyFuzzy || len(history.Jobs) != 1 || history.Jobs[0].Site != "stepstone" {
+		t.Fatalf("ReadHistory() = %+v, want the older posting under the fuzzy key", history)
+	}
+}
+
+func TestUpdateHistorySQLiteTouchesOnlyObservedRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.db")
+	jobs := []models.Job{
+		{Site: "linkedin", Title: "SRE", Company: "Acme", URL: "https://example.com/1"},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", URL: "https://example.com/2"},
+		{Site: "google"},
+	}
+	if err := WriteHistory(path, History{Jobs: jobs}); err != nil {
+		t.Fatalf("WriteHistory() error = %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+	// Rows the update must not read: a whole-history read would fail on them.
+	if _, err := db.Exec("UPDATE jobs SET data = 'not json' WHERE title = 'Data Engineer'"); err != nil {
+		t.Fatalf("UPDATE error = %v", err)
+	}
+	var invalidID int64
+	if err := db.QueryRow("SELECT id FROM jobs WHERE key IS NULL").Scan(&invalidID); err != nil {
+		t.Fatalf("SELECT error = %v", err)
+	}
+
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	input := History{Jobs: []models.Job{
+		{Site: "linkedin", Title: "SRE", Company: "Acme", URL: "https://example.com/1"},
+		{Site: "linkedin", Title: "Platform Engineer", Company: "Gamma", URL: "https://example.com/3"},
+	}}
+	stats, err := UpdateHistory(path, input, "", now)
+	if err != nil {
+		t.Fatalf("UpdateHistory() error = %v", err)
+	}
+	if stats.TotalSeen != 3 || stats.InvalidSeen != 1 || stats.Added != 1 || stats.TotalOut != 4 {
+		t.Fatalf("UpdateHistory() stats = %+v, want 3 seen, 1 invalid, 1 added, 4 out", stats)
+	}
+
+	var timesSeen int
+	if err := db.QueryRow("SELECT json_extract(data, '$.times_seen') FROM jobs WHERE title = 'SRE'").Scan(&timesSeen); err != nil {
+		t.Fatalf("SELECT error = %v", err)
+	}
+	if timesSeen != 2 {
+		t.Fatalf("times_seen = %d, want 2", timesSeen)
+	}
+	var id int64
+	if err := db.QueryRow("SELECT id FROM jobs WHERE key IS NULL").Scan(&id); err != nil || id != invalidID {
+		t.Fatalf("invalid entry id = %d (%v), want it kept as row %d", id, err, invalidID)
+	}
+}
+
+func TestReadHistoryAllowMissingSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.db")
+	history, err := ReadHistoryAllowMissing(path)
+	if err != nil {
+		t.Fatalf("ReadHistoryAllowMissing() error = %v", err)
+	}
+	if len(history.Jobs) != 0 {
+		t.Fatalf("expected empty history for missing store, got %d", len(history.Jobs))
+	}
+	if _, err := ReadJobs(path); err == nil {
+		t.Fatalf("ReadJobs() error = nil, want not-exist error")
+	}
+}
@@ -0,0 +1,33 @@
+package seen
+
+import (
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// StaleStats captures stats for stale job detection.
+type StaleStats struct {
+	TotalSeen int
+	Unknown   int
+	Stale     int
+}
+
+// Stale returns jobs last observed before cutoff. Jobs without seen times
+// (recorded before they were tracked) cannot be judged and are only counted.
+func Stale(seenJobs []models.Job, cutoff time.Time) ([]models.Job, StaleStats) {
+	stats := StaleStats{TotalSeen: len(seenJobs)}
+	stale := make([]models.Job, 0)
+	for _, job := range seenJobs {
+		last := lastObserved(job)
+		if last.IsZero() {
+			stats.Unknown++
+			continue
+		}
+		if last.Before(cutoff) {
+			stale = append(stale, job)
+		}
+	}
+	stats.Stale = len(stale)
+	return stale, stats
+}
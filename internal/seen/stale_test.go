@@ -0,0 +1,26 @@
+package seen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestStale(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{Title: "Old", Company: "Acme", FirstSeen: now.AddDate(0, -3, 0), LastSeen: now.AddDate(0, -2, 0)},
+		{Title: "Fresh", Company: "Acme", FirstSeen: now.AddDate(0, -3, 0), LastSeen: now.AddDate(0, 0, -2)},
+		{Title: "FirstOnly", Company: "Acme", FirstSeen: now.AddDate(0, -1, -5)},
+		{Title: "Legacy", Company: "Acme"},
+	}
+
+	stale, stats := Stale(jobs, now.AddDate(0, 0, -30))
+	if len(stale) != 2 || stale[0].Title != "Old" || stale[1].Title != "FirstOnly" {
+		t.Fatalf("Stale() = %+v, want Old and FirstOnly", stale)
+	}
+	if stats.TotalSeen != 4 || stats.Unknown != 1 || stats.Stale != 2 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
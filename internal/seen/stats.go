@@ -0,0 +1,95 @@
+package seen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// UnknownBucket groups jobs missing the field a HistoryStats count is keyed by.
+const UnknownBucket = "unknown"
+
+// HistoryStats summarizes a seen history.
+type HistoryStats struct {
+	Total         int
+	Invalid       int
+	DuplicateKeys int
+	Tracked       int
+	BySite        map[string]int
+	ByCompany     map[string]int
+	// ByMonth counts jobs by the month they were first seen (YYYY-MM).
+	ByMonth map[string]int
+}
+
+// Count is one bucket of a HistoryStats breakdown.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Summarize counts seenJobs by site, company and first-seen month. Invalid
+// and duplicate keys are counted with strategy, the same way Diff and Merge
+// skip them.
+func Summarize(seenJobs []models.Job, strategy KeyStrategy) HistoryStats {
+	stats := HistoryStats{
+		Total:     len(seenJobs),
+		BySite:    map[string]int{},
+		ByCompany: map[string]int{},
+		ByMonth:   map[string]int{},
+	}
+	keys := make(map[string]struct{}, len(seenJobs))
+	// Company spellings differing only in case count together under the
+	// first spelling seen.
+	companyNames := map[string]string{}
+	for _, job := range seenJobs {
+		if key, ok := strategy.Key(job); !ok {
+			stats.Invalid++
+		} else if _, exists := keys[key]; exists {
+			stats.DuplicateKeys++
+		} else {
+			keys[key] = struct{}{}
+		}
+		if job.Status != "" {
+			stats.Tracked++
+		}
+
+		stats.BySite[bucket(strings.ToLower(job.Site))]++
+		company := bucket(job.Company)
+		if name, ok := companyNames[Normalize(company)]; ok {
+			company = name
+		} else {
+			companyNames[Normalize(company)] = company
+		}
+		stats.ByCompany[company]++
+		month := UnknownBucket
+		if !job.FirstSeen.IsZero() {
+			month = job.FirstSeen.UTC().Format("2006-01")
+		}
+		stats.ByMonth[month]++
+	}
+	return stats
+}
+
+// SortedCounts returns counts ordered by count, largest first, then by name.
+func SortedCounts(counts map[string]int) []Count {
+	sorted := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		sorted = append(sorted, Count{Name: name, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func bucket(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return UnknownBucket
+	}
+	return value
+}
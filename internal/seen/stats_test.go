@@ -0,0 +1,39 @@
+package seen
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestSummarize(t *testing.T) {
+	jan := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	jobs := []models.Job{
+		{Site: "linkedin", Title: "SRE", Company: "Acme", FirstSeen: jan},
+		{Site: "LinkedIn", Title: "sre", Company: "acme", FirstSeen: jan},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", FirstSeen: feb, Status: models.StatusApplied},
+		{Site: "google"},
+	}
+
+	stats := Summarize(jobs, DefaultKeyStrategy)
+	if stats.Total != 4 || stats.Invalid != 1 || stats.DuplicateKeys != 1 || stats.Tracked != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if want := map[string]int{"linkedin": 2, "indeed": 1, "google": 1}; !reflect.DeepEqual(stats.BySite, want) {
+		t.Fatalf("BySite = %v, want %v", stats.BySite, want)
+	}
+	if want := map[string]int{"Acme": 2, "Beta": 1, UnknownBucket: 1}; !reflect.DeepEqual(stats.ByCompany, want) {
+		t.Fatalf("ByCompany = %v, want %v", stats.ByCompany, want)
+	}
+	if want := map[string]int{"2026-01": 2, "2026-02": 1, UnknownBucket: 1}; !reflect.DeepEqual(stats.ByMonth, want) {
+		t.Fatalf("ByMonth = %v, want %v", stats.ByMonth, want)
+	}
+
+	sorted := SortedCounts(stats.BySite)
+	if want := []Count{{"linkedin", 2}, {"google", 1}, {"indeed", 1}}; !reflect.DeepEqual(sorted, want) {
+		t.Fatalf("SortedCounts() = %v, want %v", sorted, want)
+	}
+}
@@ -0,0 +1,131 @@
+package seen
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+// StatusNone clears a status in SetStatus.
+const StatusNone = "none"
+
+// Statuses lists the application statuses in workflow order.
+var Statuses = []string{
+	models.StatusApplied,
+	models.StatusInterviewing,
+	models.StatusOffer,
+	models.StatusRejected,
+	models.StatusIgnored,
+}
+
+// ParseStatus validates an application status name.
+func ParseStatus(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, status := range Statuses {
+		if value == status {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid status %q: expected one of %s", value, strings.Join(Statuses, ", "))
+}
+
+// Find returns the indexes of jobs matching ref: a job ID, "site:id", or a job
+// or merged source URL.
+func Find(jobs []models.Job, ref string) []int {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil
+	}
+	normalizedRef := Normalize(ref)
+
+	var matches []int
+	for i, job := range jobs {
+		if matchesRef(job, ref, normalizedRef) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+func matchesRef(job models.Job, ref string, normalizedRef string) bool {
+	if id := strings.TrimSpace(job.ID); id != "" {
+		if ref == id || strings.EqualFold(ref, job.Site+":"+id) {
+			return true
+		}
+	}
+	if Normalize(job.URL) == normalizedRef {
+		return true
+	}
+	for _, source := range job.Sources {
+		if Normalize(source.URL) == normalizedRef {
+			return true
+		}
+	}
+	return false
+}
+
+// SetStatus records status and note on every job matching ref and returns
+// the number of jobs updated. StatusNone clears the status.
+func SetStatus(jobs []models.Job, ref string, status string, note string, now time.Time) (int, error) {
+	if status != StatusNone {
+		parsed, err := ParseStatus(status)
+		if err != nil {
+			return 0, err
+		}
+		status = parsed
+	}
+
+	matches := Find(jobs, ref)
+	for _, i := range matches {
+		if status == StatusNone {
+			jobs[i].Status, jobs[i].StatusNote = "", ""
+		} else {
+			jobs[i].Status, jobs[i].StatusNote = status, strings.TrimSpace(note)
+		}
+		jobs[i].StatusUpdatedAt = now
+	}
+	return len(matches), nil
+}
+
+// ApplyStatus copies the application status of seen entries onto jobs with
+// the same key, drops jobs marked ignored, and returns the kept jobs and the
+// number dropped.
+func ApplyStatus(jobs []models.Job, seenJobs []models.Job, strategy KeyStrategy) ([]models.Job, int) {
+	statusByKey := make(map[string]models.Job)
+	for _, job := range seenJobs {
+		if job.Status == "" {
+			continue
+		}
+		key, ok := strategy.Key(job)
+		if !ok {
+			continue
+		}
+		if _, exists := statusByKey[key]; !exists {
+			statusByKey[key] = job
+		}
+	}
+
+	kept := make([]models.Job, 0, len(jobs))
+	ignored := 0
+	for _, job := range jobs {
+		if key, ok := strategy.Key(job); ok {
+			if seenJob, exists := statusByKey[key]; exists {
+				copyStatus(&job, seenJob)
+			}
+		}
+		if job.Status == models.StatusIgnored {
+			ignored++
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept, ignored
+}
+
+func copyStatus(job *models.Job, from models.Job) {
+	job.Status = from.Status
+	job.StatusNote = from.StatusNote
+	job.StatusUpdatedAt = from.StatusUpdatedAt
+}
@@ -0,0 +1,102 @@
+package seen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimezsa/jobcli/internal/models"
+)
+
+func TestParseStatus(t *testing.T) {
+	if got, err := ParseStatus(" Applied "); err != nil || got != models.StatusApplied {
+		t.Fatalf("ParseStatus() = %q, %v; want applied", got, err)
+	}
+	if _, err := ParseStatus("hired"); err == nil {
+		t.Fatalf("ParseStatus(hired) error = nil, want error")
+	}
+}
+
+func TestSetStatusMatchesRefs(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	newJobs := func() []models.Job {
+		return []models.Job{
+			{Site: "linkedin", ID: "123", Title: "SRE", Company: "Acme", URL: "https://www.linkedin.com/jobs/view/123"},
+			{Site: "indeed", Title: "Data Engineer", Company: "Beta", URL: "https://de.indeed.com/viewjob?jk=abc",
+				Sources: []models.JobSource{{Site: "stepstone", URL: "https://www.stepstone.de/job--42.html"}}},
+		}
+	}
+
+	tests := []struct {
+		name string
+		ref  string
+		want int
+	}{
+		{name: "id", ref: "123", want: 0},
+		{name: "site and id", ref: "LinkedIn:123", want: 0},
+		{name: "url", ref: "https://de.indeed.com/viewjob?jk=abc", want: 1},
+		{name: "source url", ref: "https://www.stepstone.de/job--42.html", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := newJobs()
+			updated, err := SetStatus(jobs, tt.ref, "applied", " sent CV ", now)
+			if err != nil {
+				t.Fatalf("SetStatus() error = %v", err)
+			}
+			if updated != 1 {
+				t.Fatalf("SetStatus() updated = %d, want 1", updated)
+			}
+			job := jobs[tt.want]
+			if job.Status != models.StatusApplied || job.StatusNote != "sent CV" || !job.StatusUpdatedAt.Equal(now) {
+				t.Fatalf("job = %+v, want applied with note", job)
+			}
+		})
+	}
+
+	jobs := newJobs()
+	if updated, _ := SetStatus(jobs, "unknown", "applied", "", now); updated != 0 {
+		t.Fatalf("SetStatus(unknown) updated = %d, want 0", updated)
+	}
+	if _, err := SetStatus(jobs, "123", "hired", "", now); err == nil {
+		t.Fatalf("SetStatus(hired) error = nil, want error")
+	}
+}
+
+func TestSetStatusNoneClears(t *testing.T) {
+	jobs := []models.Job{{Site: "linkedin", ID: "1", Status: models.StatusOffer, StatusNote: "call back"}}
+	if _, err := SetStatus(jobs, "1", StatusNone, "ignored note", time.Now()); err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+	if jobs[0].Status != "" || jobs[0].StatusNote != "" {
+		t.Fatalf("job = %+v, want status cleared", jobs[0])
+	}
+}
+
+func TestApplyStatus(t *testing.T) {
+	seenJobs := []models.Job{
+		{Site: "linkedin", Title: "SRE", Company: "Acme", Status: models.StatusInterviewing, StatusNote: "round 2"},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta", Status: models.StatusIgnored},
+	}
+	jobs := []models.Job{
+		{Site: "linkedin", Title: "SRE", Company: "Acme"},
+		{Site: "indeed", Title: "Data Engineer", Company: "Beta"},
+		{Site: "google", Title: "Analyst", Company: "Gamma"},
+	}
+
+	kept, ignored := ApplyStatus(jobs, seenJobs, DefaultKeyStrategy)
+	if ignored != 1 {
+		t.Fatalf("ignored = %d, want 1", ignored)
+	}
+	if len(kept) != 2 {
+		t.Fatalf("len(kept) = %d, want 2", len(kept))
+	}
+	if kept[0].Status != models.StatusInterviewing || kept[0].StatusNote != "round 2" {
+		t.Fatalf("kept[0] = %+v, want interviewing status", kept[0])
+	}
+	if kept[1].Status != "" {
+		t.Fatalf("kept[1].Status = %q, want empty", kept[1].Status)
+	}
+	if jobs[0].Status != "" {
+		t.Fatalf("ApplyStatus() modified its input")
+	}
+}